@@ -0,0 +1,24 @@
+package release
+
+import "fmt"
+
+// AddressSpaceLimit returns the soft RLIMIT_AS limit, the maximum size of
+// the process's virtual address space in bytes. Unlimited is reported as
+// RLimitInfinity. It returns ErrNotSupported on non-Linux platforms
+func AddressSpaceLimit() (uint64, error) {
+	return addressSpaceLimit()
+}
+
+// MinAddressSpaceCondition returns a condition that fails when the
+// virtual address space limit is below the given number of bytes. The Go
+// runtime reserves address space well beyond what it uses, so a tight
+// RLIMIT_AS can make allocations fail with plenty of memory free
+func MinAddressSpaceCondition(bytes uint64) Condition {
+	return newDetailedCondition("min-address-space", fmt.Sprintf("Address space limit is at least %d bytes", bytes), func() (bool, string, error) {
+		limit, err := AddressSpaceLimit()
+		if err != nil {
+			return false, "", err
+		}
+		return limit >= bytes, fmt.Sprintf("address space limit is %s bytes, need %d", formatLimit(limit), bytes), nil
+	})
+}
@@ -0,0 +1,8 @@
+package release
+
+import "golang.org/x/sys/unix"
+
+func addressSpaceLimit() (uint64, error) {
+	soft, _, err := getrlimit(unix.RLIMIT_AS)
+	return soft, err
+}
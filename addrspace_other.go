@@ -0,0 +1,7 @@
+//go:build !linux
+
+package release
+
+func addressSpaceLimit() (uint64, error) {
+	return 0, ErrNotSupported
+}
@@ -0,0 +1,33 @@
+package release
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestMinAddressSpaceCondition(t *testing.T) {
+	limit, err := AddressSpaceLimit()
+	if errors.Is(err, ErrNotSupported) {
+		if r := MinAddressSpaceCondition(1).evaluate(); !errors.Is(r.Error, ErrNotSupported) {
+			t.Errorf("Expected ErrNotSupported, got %+v", r)
+		}
+		return
+	}
+	if err != nil {
+		t.Fatalf("AddressSpaceLimit() error = %v", err)
+	}
+
+	if r := MinAddressSpaceCondition(limit).evaluate(); !r.Passed {
+		t.Errorf("MinAddressSpaceCondition(%d) should pass: %+v", limit, r)
+	}
+	if limit == RLimitInfinity {
+		if r := MinAddressSpaceCondition(1 << 40).evaluate(); !strings.Contains(r.Detail, "unlimited") {
+			t.Errorf("Expected an unlimited detail, got %+v", r)
+		}
+		return
+	}
+	if r := MinAddressSpaceCondition(limit + 1).evaluate(); r.Passed {
+		t.Errorf("MinAddressSpaceCondition(%d) should fail: %+v", limit+1, r)
+	}
+}
@@ -0,0 +1,68 @@
+package release
+
+import "fmt"
+
+// ConditionAggregate summarizes one condition's outcomes across runs
+type ConditionAggregate struct {
+	Name   string
+	Runs   int
+	Passed int
+}
+
+// PassRate returns the fraction of runs in which the condition passed
+func (a ConditionAggregate) PassRate() float64 {
+	if a.Runs == 0 {
+		return 0
+	}
+	return float64(a.Passed) / float64(a.Runs)
+}
+
+// Flaky returns true if the condition both passed and failed across runs
+func (a ConditionAggregate) Flaky() bool {
+	return a.Passed > 0 && a.Passed < a.Runs
+}
+
+// String returns e.g. "db-reachable: passed 4/5 times"
+func (a ConditionAggregate) String() string {
+	return fmt.Sprintf("%s: passed %d/%d times", a.Name, a.Passed, a.Runs)
+}
+
+// AggregatedResults is the per-condition summary of several runs, in the
+// order conditions were first seen
+type AggregatedResults []ConditionAggregate
+
+// AggregateRuns summarizes per-condition pass rates across several runs
+// of a gate (see TestResult.OK), to surface intermittently failing
+// conditions. Results are matched by name
+func AggregateRuns(runs ...TestResults) AggregatedResults {
+	var aggregated AggregatedResults
+	index := make(map[string]int)
+
+	for _, run := range runs {
+		for _, r := range run {
+			i, ok := index[r.Name]
+			if !ok {
+				i = len(aggregated)
+				index[r.Name] = i
+				aggregated = append(aggregated, ConditionAggregate{Name: r.Name})
+			}
+			aggregated[i].Runs++
+			if r.OK() {
+				aggregated[i].Passed++
+			}
+		}
+	}
+
+	return aggregated
+}
+
+// Flaky returns the conditions that both passed and failed across runs
+func (aggregated AggregatedResults) Flaky() AggregatedResults {
+	var flaky AggregatedResults
+	for _, a := range aggregated {
+		if a.Flaky() {
+			flaky = append(flaky, a)
+		}
+	}
+	return flaky
+}
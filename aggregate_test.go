@@ -0,0 +1,40 @@
+package release
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestAggregateRuns(t *testing.T) {
+	runs := []TestResults{
+		{{Name: "stable", Passed: true}, {Name: "flaky", Passed: true}},
+		{{Name: "stable", Passed: true}, {Name: "flaky", Passed: false}},
+		{{Name: "stable", Passed: true}, {Name: "flaky", Error: errors.New("timeout")}, {Name: "new", Passed: false}},
+	}
+
+	aggregated := AggregateRuns(runs...)
+	if len(aggregated) != 3 {
+		t.Fatalf("Expected 3 aggregates, got %d", len(aggregated))
+	}
+
+	stable, flaky, added := aggregated[0], aggregated[1], aggregated[2]
+	if stable.Name != "stable" || stable.Runs != 3 || stable.Passed != 3 || stable.Flaky() {
+		t.Errorf("Unexpected stable aggregate: %+v", stable)
+	}
+	if flaky.Passed != 1 || !flaky.Flaky() {
+		t.Errorf("Unexpected flaky aggregate: %+v", flaky)
+	}
+	if flaky.String() != "flaky: passed 1/3 times" {
+		t.Errorf("String() = %q", flaky.String())
+	}
+	if added.Runs != 1 || added.PassRate() != 0 {
+		t.Errorf("Unexpected aggregate for a condition added later: %+v", added)
+	}
+
+	if got := aggregated.Flaky(); len(got) != 1 || got[0].Name != "flaky" {
+		t.Errorf("Flaky() = %v, want only the flaky condition", got)
+	}
+	if (ConditionAggregate{}).PassRate() != 0 {
+		t.Error("PassRate of an empty aggregate should be 0")
+	}
+}
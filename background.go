@@ -0,0 +1,84 @@
+package release
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrPending is returned by background conditions whose first check has
+// not completed yet
+var ErrPending = errors.New("background check has not completed yet")
+
+// AddBackground adds a condition whose check runs in a background
+// goroutine, immediately and then every interval. Test runs read the
+// last-known result without blocking, which suits readiness endpoints
+// that must respond instantly. The detail reports how old the result is.
+// Until the first check completes, the condition fails with ErrPending.
+//
+// The returned function stops the goroutine; it is safe to call more than
+// once. The last result remains readable after stopping. Like
+// time.NewTicker, it panics if interval is not positive
+func (cs *ConditionSet) AddBackground(name, description string, interval time.Duration, check func() (bool, error)) func() {
+	if interval <= 0 {
+		panic(fmt.Sprintf("release: non-positive interval %s for background condition %q", interval, name))
+	}
+	status := &backgroundStatus{}
+	done := make(chan struct{})
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			status.update(check)
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	cs.AddDetailed(name, description, status.read)
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { close(done) })
+	}
+}
+
+// backgroundStatus is the last-known result of a background check
+type backgroundStatus struct {
+	mu      sync.RWMutex
+	checked time.Time
+	ok      bool
+	err     error
+}
+
+// update runs check and records its result, converting a panic into an
+// error so that the goroutine survives
+func (s *backgroundStatus) update(check func() (bool, error)) {
+	ok, err := func() (ok bool, err error) {
+		defer func() {
+			if v := recover(); v != nil {
+				ok, err = false, newPanicError(v)
+			}
+		}()
+		return check()
+	}()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.checked, s.ok, s.err = now(), ok, err
+}
+
+// read returns the last-known result as a detailed check
+func (s *backgroundStatus) read() (bool, string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.checked.IsZero() {
+		return false, "", ErrPending
+	}
+	return s.ok, fmt.Sprintf("last checked %s ago", now().Sub(s.checked).Round(time.Millisecond)), s.err
+}
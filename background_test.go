@@ -0,0 +1,79 @@
+package release
+
+import (
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestAddBackground(t *testing.T) {
+	var healthy atomic.Bool
+	healthy.Store(true)
+	unblock := make(chan struct{})
+
+	cs := NewConditionSet()
+	stop := cs.AddBackground("upstream", "Upstream healthy", time.Millisecond, func() (bool, error) {
+		<-unblock
+		return healthy.Load(), nil
+	})
+	defer stop()
+
+	if r := cs.TestAll()[0]; !errors.Is(r.Error, ErrPending) {
+		t.Errorf("Before the first check, expected ErrPending, got %+v", r)
+	}
+
+	close(unblock)
+	waitFor(t, func() bool { return cs.TestAll().AllPassed() })
+	if r := cs.TestAll()[0]; !strings.Contains(r.Detail, "last checked") {
+		t.Errorf("Unexpected detail: %q", r.Detail)
+	}
+
+	healthy.Store(false)
+	waitFor(t, func() bool { return !cs.TestAll().AllPassed() })
+
+	stop()
+	stop()
+	if r := cs.TestAll()[0]; r.Passed || r.Error != nil {
+		t.Errorf("The last result should remain readable after stopping: %+v", r)
+	}
+}
+
+func TestAddBackgroundPanic(t *testing.T) {
+	cs := NewConditionSet()
+	stop := cs.AddBackground("panics", "Panicking check", time.Hour, func() (bool, error) {
+		panic("boom")
+	})
+	defer stop()
+
+	waitFor(t, func() bool { return !errors.Is(cs.TestAll()[0].Error, ErrPending) })
+	var pe *PanicError
+	if r := cs.TestAll()[0]; !errors.As(r.Error, &pe) {
+		t.Errorf("Expected a PanicError, got %+v", r)
+	}
+}
+
+// waitFor polls cond until it holds or a deadline passes
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("Timed out waiting for condition")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestAddBackgroundInvalidInterval(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("AddBackground() with a zero interval should panic at the call site")
+		}
+	}()
+	NewConditionSet().AddBackground("never", "Zero interval", 0, func() (bool, error) {
+		t.Error("The check should not run")
+		return true, nil
+	})
+}
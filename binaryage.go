@@ -0,0 +1,62 @@
+package release
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// ExecutableModTime returns the modification time of the running
+// executable, which is usually when it was built or installed
+func ExecutableModTime() (time.Time, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return time.Time{}, err
+	}
+	fi, err := os.Stat(exe)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return fi.ModTime(), nil
+}
+
+// MaxBinaryAgeCondition returns a condition that fails when the binary is
+// older than maxAge, catching redeploys that left an old binary running.
+// The age is measured from the executable's modification time. The
+// embedded vcs.time is the commit time, not the build time, so it is only
+// used as a lower bound on the build time, e.g. when a copy preserved an
+// older mtime, and as a fallback when the executable cannot be stat'ed; an
+// age measured from it may overstate the binary's real age
+func MaxBinaryAgeCondition(maxAge time.Duration) Condition {
+	return newDetailedCondition("max-binary-age", fmt.Sprintf("Binary is at most %s old", maxAge), func() (bool, string, error) {
+		return checkBinaryAge(GetBuildInfo().VCSTime, ExecutableModTime, now(), maxAge)
+	})
+}
+
+// checkBinaryAge compares the binary's age at t with maxAge, using the
+// later of modTime and vcsTime as the build time
+func checkBinaryAge(vcsTime string, modTime func() (time.Time, error), t time.Time, maxAge time.Duration) (bool, string, error) {
+	var committed time.Time
+	if vcsTime != "" {
+		parsed, err := time.Parse(time.RFC3339, vcsTime)
+		if err != nil {
+			return false, "", fmt.Errorf("parsing vcs.time: %w", err)
+		}
+		committed = parsed
+	}
+
+	built, source := committed, "VCS commit time"
+	mtime, err := modTime()
+	switch {
+	case err == nil && mtime.After(committed):
+		built, source = mtime, "executable modification time"
+	case err != nil && committed.IsZero():
+		return false, "", err
+	}
+
+	age := t.Sub(built).Round(time.Second)
+	if age > maxAge {
+		return false, fmt.Sprintf("binary is %s old by %s, exceeding %s", age, source, maxAge), nil
+	}
+	return true, fmt.Sprintf("binary is %s old by %s", age, source), nil
+}
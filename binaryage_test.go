@@ -0,0 +1,65 @@
+package release
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExecutableModTime(t *testing.T) {
+	mtime, err := ExecutableModTime()
+	if err != nil {
+		t.Skipf("ExecutableModTime() error = %v", err)
+	}
+	if mtime.IsZero() || mtime.After(time.Now()) {
+		t.Errorf("ExecutableModTime() = %v, want a time in the past", mtime)
+	}
+}
+
+func TestCheckBinaryAge(t *testing.T) {
+	at := time.Date(2024, time.June, 10, 12, 0, 0, 0, time.UTC)
+	mtime := func() (time.Time, error) { return at.Add(-2 * time.Hour), nil }
+	noStat := func() (time.Time, error) { return time.Time{}, errors.New("stat failed") }
+
+	tests := []struct {
+		name    string
+		vcsTime string
+		modTime func() (time.Time, error)
+		max     time.Duration
+		want    bool
+		detail  string
+		wantErr bool
+	}{
+		{"vcs fallback fresh", "2024-06-10T11:00:00Z", noStat, 2 * time.Hour, true, "1h0m0s old by VCS commit time", false},
+		{"vcs fallback stale", "2024-06-01T12:00:00Z", noStat, 24 * time.Hour, false, "exceeding 24h0m0s", false},
+		{"old commit rebuilt", "2024-06-01T12:00:00Z", mtime, 3 * time.Hour, true, "2h0m0s old by executable modification time", false},
+		{"mtime before commit", "2024-06-10T11:00:00Z", mtime, 3 * time.Hour, true, "1h0m0s old by VCS commit time", false},
+		{"mtime fresh", "", mtime, 3 * time.Hour, true, "2h0m0s old by executable modification time", false},
+		{"mtime stale", "", mtime, time.Hour, false, "exceeding 1h0m0s", false},
+		{"bad vcs time", "yesterday", mtime, time.Hour, false, "", true},
+		{"stat error", "", noStat, time.Hour, false, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, detail, err := checkBinaryAge(tt.vcsTime, tt.modTime, at, tt.max)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("checkBinaryAge() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if ok != tt.want || !strings.Contains(detail, tt.detail) {
+				t.Errorf("checkBinaryAge() = %v, %q, want %v containing %q", ok, detail, tt.want, tt.detail)
+			}
+		})
+	}
+}
+
+func TestMaxBinaryAgeCondition(t *testing.T) {
+	c := MaxBinaryAgeCondition(100 * 365 * 24 * time.Hour)
+	if c.Name != "max-binary-age" {
+		t.Errorf("Name = %q", c.Name)
+	}
+	if r := c.evaluate(); r.Error == nil && !r.Passed {
+		t.Errorf("MaxBinaryAgeCondition(100 years) = %+v, want pass", r)
+	}
+}
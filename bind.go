@@ -0,0 +1,48 @@
+package release
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+)
+
+// CanBindTCP reports whether the process can listen on the given TCP
+// port on all interfaces (0 for any free port). The listener is closed
+// immediately. A port already in use or a privileged port the process may
+// not bind report false without an error; other failures are errors
+func CanBindTCP(port int) (bool, error) {
+	ok, _, err := bindTCP(port)
+	return ok, err
+}
+
+// PortBindableCondition returns a condition that fails when the process
+// cannot listen on the given TCP port, explaining whether the port is
+// already in use or requires privileges
+func PortBindableCondition(port int) Condition {
+	return newDetailedCondition(fmt.Sprintf("port-bindable-%d", port), fmt.Sprintf("TCP port %d can be bound", port), func() (bool, string, error) {
+		return bindTCP(port)
+	})
+}
+
+// bindTCP attempts to listen on port and classifies the failure
+func bindTCP(port int) (bool, string, error) {
+	ln, err := net.Listen("tcp", net.JoinHostPort("", strconv.Itoa(port)))
+	switch {
+	case err == nil:
+		addr := ln.Addr().String()
+		ln.Close()
+		return true, fmt.Sprintf("listened on %s", addr), nil
+	case isAddrInUse(err):
+		return false, fmt.Sprintf("port %d is already in use", port), nil
+	case isPermissionDenied(err):
+		return false, fmt.Sprintf("permission denied binding port %d (privileged port?)", port), nil
+	}
+	return false, "", err
+}
+
+// isPermissionDenied reports whether a listen error is a permission error
+func isPermissionDenied(err error) bool {
+	return errors.Is(err, os.ErrPermission) || errors.Is(err, errAccessDenied)
+}
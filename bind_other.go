@@ -0,0 +1,15 @@
+//go:build !windows && !plan9
+
+package release
+
+import (
+	"errors"
+	"syscall"
+)
+
+// errAccessDenied is the errno for binding a privileged port
+var errAccessDenied error = syscall.EACCES
+
+func isAddrInUse(err error) bool {
+	return errors.Is(err, syscall.EADDRINUSE)
+}
@@ -0,0 +1,13 @@
+package release
+
+import "os"
+
+// errAccessDenied duplicates os.ErrPermission; Plan 9 reports string
+// errors rather than errnos
+var errAccessDenied = os.ErrPermission
+
+// isAddrInUse always reports false: Plan 9 has no errno for it, so the
+// failure is returned as an error
+func isAddrInUse(err error) bool {
+	return false
+}
@@ -0,0 +1,26 @@
+package release
+
+import (
+	"net"
+	"strings"
+	"testing"
+)
+
+func TestCanBindTCP(t *testing.T) {
+	ok, err := CanBindTCP(0)
+	if err != nil || !ok {
+		t.Fatalf("CanBindTCP(0) = %v, %v, want true", ok, err)
+	}
+
+	ln, err := net.Listen("tcp", ":0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	port := ln.Addr().(*net.TCPAddr).Port
+
+	r := PortBindableCondition(port).evaluate()
+	if r.Passed || r.Error != nil || !strings.Contains(r.Detail, "already in use") {
+		t.Errorf("PortBindableCondition(%d) on a used port = %+v", port, r)
+	}
+}
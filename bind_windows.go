@@ -0,0 +1,14 @@
+package release
+
+import (
+	"errors"
+
+	"golang.org/x/sys/windows"
+)
+
+// errAccessDenied is the Winsock error for binding a forbidden port
+var errAccessDenied error = windows.WSAEACCES
+
+func isAddrInUse(err error) bool {
+	return errors.Is(err, windows.WSAEADDRINUSE)
+}
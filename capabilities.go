@@ -0,0 +1,134 @@
+package release
+
+import (
+	"bufio"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// capabilityBits maps Linux capability names, without the CAP_ prefix,
+// to their bit in the capability sets
+var capabilityBits = map[string]uint{
+	"CHOWN":              0,
+	"DAC_OVERRIDE":       1,
+	"DAC_READ_SEARCH":    2,
+	"FOWNER":             3,
+	"FSETID":             4,
+	"KILL":               5,
+	"SETGID":             6,
+	"SETUID":             7,
+	"SETPCAP":            8,
+	"LINUX_IMMUTABLE":    9,
+	"NET_BIND_SERVICE":   10,
+	"NET_BROADCAST":      11,
+	"NET_ADMIN":          12,
+	"NET_RAW":            13,
+	"IPC_LOCK":           14,
+	"IPC_OWNER":          15,
+	"SYS_MODULE":         16,
+	"SYS_RAWIO":          17,
+	"SYS_CHROOT":         18,
+	"SYS_PTRACE":         19,
+	"SYS_PACCT":          20,
+	"SYS_ADMIN":          21,
+	"SYS_BOOT":           22,
+	"SYS_NICE":           23,
+	"SYS_RESOURCE":       24,
+	"SYS_TIME":           25,
+	"SYS_TTY_CONFIG":     26,
+	"MKNOD":              27,
+	"LEASE":              28,
+	"AUDIT_WRITE":        29,
+	"AUDIT_CONTROL":      30,
+	"SETFCAP":            31,
+	"MAC_OVERRIDE":       32,
+	"MAC_ADMIN":          33,
+	"SYSLOG":             34,
+	"WAKE_ALARM":         35,
+	"BLOCK_SUSPEND":      36,
+	"AUDIT_READ":         37,
+	"PERFMON":            38,
+	"BPF":                39,
+	"CHECKPOINT_RESTORE": 40,
+}
+
+// HasCapability reports whether the process has the Linux capability name
+// in its effective set. name is case-insensitive and the CAP_ prefix is
+// optional, so "CAP_NET_BIND_SERVICE" and "net_bind_service" are the same.
+// It returns ErrNotSupported on other platforms
+func HasCapability(name string) (bool, error) {
+	bit, err := capabilityBit(name)
+	if err != nil {
+		return false, err
+	}
+	effective, err := effectiveCapabilities()
+	if err != nil {
+		return false, err
+	}
+	return effective&(1<<bit) != 0, nil
+}
+
+// RequiredCapabilitiesCondition returns a condition that fails unless the
+// process has every capability in caps (see HasCapability), catching
+// permission problems before the server tries a privileged operation.
+// The missing capabilities are reported by name
+func RequiredCapabilitiesCondition(caps ...string) Condition {
+	return newDetailedCondition("required-capabilities", fmt.Sprintf("Process has capabilities %v", caps), func() (bool, string, error) {
+		effective, err := effectiveCapabilities()
+		if err != nil {
+			return false, "", err
+		}
+		return checkCapabilities(effective, caps)
+	})
+}
+
+// checkCapabilities reports which of caps are missing from effective
+func checkCapabilities(effective uint64, caps []string) (bool, string, error) {
+	var missing []string
+	for _, name := range caps {
+		bit, err := capabilityBit(name)
+		if err != nil {
+			return false, "", err
+		}
+		if effective&(1<<bit) == 0 {
+			missing = append(missing, "CAP_"+normalizeCapability(name))
+		}
+	}
+	if len(missing) > 0 {
+		return false, fmt.Sprintf("missing %s", strings.Join(missing, ", ")), nil
+	}
+	return true, fmt.Sprintf("all %d capabilities present", len(caps)), nil
+}
+
+// capabilityBit returns the bit of the named capability
+func capabilityBit(name string) (uint, error) {
+	bit, ok := capabilityBits[normalizeCapability(name)]
+	if !ok {
+		return 0, fmt.Errorf("unknown capability %q", name)
+	}
+	return bit, nil
+}
+
+// normalizeCapability upper-cases name and strips the CAP_ prefix
+func normalizeCapability(name string) string {
+	name = strings.ToUpper(strings.TrimSpace(name))
+	return strings.TrimPrefix(name, "CAP_")
+}
+
+// statusCapEff returns the "CapEff:" field of /proc/self/status contents
+func statusCapEff(status string) (uint64, bool) {
+	scanner := bufio.NewScanner(strings.NewReader(status))
+	for scanner.Scan() {
+		name, value, ok := strings.Cut(scanner.Text(), ":")
+		if !ok || name != "CapEff" {
+			continue
+		}
+		caps, err := strconv.ParseUint(strings.TrimSpace(value), 16, 64)
+		if err != nil {
+			return 0, false
+		}
+		return caps, true
+	}
+	return 0, false
+}
@@ -0,0 +1,18 @@
+package release
+
+import (
+	"fmt"
+	"os"
+)
+
+func effectiveCapabilities() (uint64, error) {
+	data, err := os.ReadFile(procSelfStatusPath)
+	if err != nil {
+		return 0, err
+	}
+	caps, ok := statusCapEff(string(data))
+	if !ok {
+		return 0, fmt.Errorf("no CapEff field in %s", procSelfStatusPath)
+	}
+	return caps, nil
+}
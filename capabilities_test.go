@@ -0,0 +1,91 @@
+package release
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestStatusCapEff(t *testing.T) {
+	tests := []struct {
+		status string
+		want   uint64
+		wantOK bool
+	}{
+		{"Name:\tgo\nCapInh:\t0000000000000000\nCapEff:\t0000000000000400\n", 1 << 10, true},
+		{"CapEff:\t000001ffffffffff\n", 1<<41 - 1, true},
+		{"Name:\tgo\nCapPrm:\t0000000000000400\n", 0, false},
+		{"CapEff:\tnope\n", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := statusCapEff(tt.status)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("statusCapEff(%q) = %x, %v; want %x, %v", tt.status, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestCheckCapabilities(t *testing.T) {
+	const netBind = 1 << 10
+
+	tests := []struct {
+		name      string
+		effective uint64
+		caps      []string
+		want      bool
+		detail    string
+		wantErr   bool
+	}{
+		{"present", netBind, []string{"CAP_NET_BIND_SERVICE"}, true, "all 1 capabilities present", false},
+		{"short lower-case name", netBind, []string{"net_bind_service"}, true, "", false},
+		{"missing", netBind, []string{"NET_BIND_SERVICE", "CAP_NET_RAW", "sys_admin"}, false, "missing CAP_NET_RAW, CAP_SYS_ADMIN", false},
+		{"none required", 0, nil, true, "", false},
+		{"unknown", netBind, []string{"CAP_FLY"}, false, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, detail, err := checkCapabilities(tt.effective, tt.caps)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("checkCapabilities() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if ok != tt.want || !strings.Contains(detail, tt.detail) {
+				t.Errorf("checkCapabilities() = %v, %q, want %v containing %q", ok, detail, tt.want, tt.detail)
+			}
+		})
+	}
+}
+
+func TestHasCapability(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		if _, err := HasCapability("NET_RAW"); !errors.Is(err, ErrNotSupported) {
+			t.Errorf("HasCapability() error = %v, want ErrNotSupported", err)
+		}
+		return
+	}
+
+	orig := procSelfStatusPath
+	defer func() { procSelfStatusPath = orig }()
+	procSelfStatusPath = filepath.Join(t.TempDir(), "status")
+	if err := os.WriteFile(procSelfStatusPath, []byte("Name:\tgo\nCapEff:\t0000000000000400\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if ok, err := HasCapability("CAP_NET_BIND_SERVICE"); !ok || err != nil {
+		t.Errorf("HasCapability(CAP_NET_BIND_SERVICE) = %v, %v; want true", ok, err)
+	}
+	if ok, err := HasCapability("CAP_SYS_ADMIN"); ok || err != nil {
+		t.Errorf("HasCapability(CAP_SYS_ADMIN) = %v, %v; want false", ok, err)
+	}
+	if _, err := HasCapability("CAP_FLY"); err == nil {
+		t.Error("HasCapability() should reject unknown capabilities")
+	}
+
+	r := RequiredCapabilitiesCondition("CAP_NET_BIND_SERVICE", "CAP_NET_RAW").evaluate()
+	if r.Passed || r.Error != nil || r.Detail != "missing CAP_NET_RAW" {
+		t.Errorf("RequiredCapabilitiesCondition() = %+v, want missing CAP_NET_RAW", r)
+	}
+}
@@ -0,0 +1,51 @@
+package release
+
+import (
+	"crypto/x509"
+	"fmt"
+	"runtime"
+)
+
+// SystemCertPoolAvailable returns the number of CA certificates in the
+// system pool. It returns ErrNotSupported on darwin, iOS and Windows,
+// where Go delegates verification to the OS and the pool cannot be
+// enumerated
+func SystemCertPoolAvailable() (int, error) {
+	if usesPlatformVerifier(runtime.GOOS) {
+		return 0, ErrNotSupported
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		return 0, err
+	}
+	// Subjects is deprecated only for pools backed by the platform
+	// verifier, which are excluded above
+	return len(pool.Subjects()), nil
+}
+
+// CACertsCondition returns a condition that fails when the system CA
+// pool holds fewer than minCerts certificates, e.g. in a scratch
+// container without a CA bundle. The pool must never be empty, so a
+// minCerts below 1 is treated as 1
+func CACertsCondition(minCerts int) Condition {
+	if minCerts < 1 {
+		minCerts = 1
+	}
+	return newDetailedCondition("ca-certs", fmt.Sprintf("At least %d system CA certificates", minCerts), func() (bool, string, error) {
+		n, err := SystemCertPoolAvailable()
+		if err != nil {
+			return false, "", err
+		}
+		return n >= minCerts, fmt.Sprintf("found %d CA certificates, need %d", n, minCerts), nil
+	})
+}
+
+// usesPlatformVerifier reports whether crypto/x509 verifies against the
+// OS trust store rather than a loaded certificate bundle
+func usesPlatformVerifier(goos string) bool {
+	switch goos {
+	case "darwin", "ios", "windows":
+		return true
+	}
+	return false
+}
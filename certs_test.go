@@ -0,0 +1,35 @@
+package release
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCACertsCondition(t *testing.T) {
+	n, err := SystemCertPoolAvailable()
+	if errors.Is(err, ErrNotSupported) {
+		if r := CACertsCondition(1).evaluate(); !errors.Is(r.Error, ErrNotSupported) {
+			t.Errorf("Expected ErrNotSupported, got %+v", r)
+		}
+		return
+	}
+	if err != nil {
+		t.Skipf("cannot load system cert pool: %v", err)
+	}
+	t.Logf("System CA certificates: %d", n)
+
+	if r := CACertsCondition(n + 1).evaluate(); r.Passed {
+		t.Errorf("CACertsCondition(%d) should fail: %+v", n+1, r)
+	}
+	if r := CACertsCondition(0).evaluate(); r.Passed != (n > 0) {
+		t.Errorf("CACertsCondition(0) with %d certs = %+v", n, r)
+	}
+}
+
+func TestUsesPlatformVerifier(t *testing.T) {
+	for goos, want := range map[string]bool{"darwin": true, "windows": true, "ios": true, "linux": false, "freebsd": false} {
+		if got := usesPlatformVerifier(goos); got != want {
+			t.Errorf("usesPlatformVerifier(%q) = %v, want %v", goos, got, want)
+		}
+	}
+}
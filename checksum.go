@@ -0,0 +1,81 @@
+package release
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"sort"
+	"strings"
+)
+
+// fileSHA256 returns the hex-encoded SHA-256 of the file at path
+func fileSHA256(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// verifyChecksum compares the file's checksum with the expected one. A
+// missing file is reported as a failure rather than an error
+func verifyChecksum(path, sha256hex string) (bool, string, error) {
+	actual, err := fileSHA256(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, fmt.Sprintf("%s: file not found", path), nil
+	}
+	if err != nil {
+		return false, "", fmt.Errorf("hashing %s: %w", path, err)
+	}
+	if !strings.EqualFold(actual, sha256hex) {
+		return false, fmt.Sprintf("%s: checksum mismatch (got %s, want %s)", path, actual, sha256hex), nil
+	}
+	return true, fmt.Sprintf("%s: checksum ok", path), nil
+}
+
+// FileChecksumCondition returns a condition that passes when the SHA-256
+// of the file at path matches sha256hex. The detail distinguishes a
+// missing file from a mismatch; other read failures are errors
+func FileChecksumCondition(path, sha256hex string) Condition {
+	return newDetailedCondition("file-checksum-"+path, fmt.Sprintf("%s matches its expected SHA-256", path), func() (bool, string, error) {
+		return verifyChecksum(path, sha256hex)
+	})
+}
+
+// FilesChecksumCondition returns a condition that verifies several files
+// against their expected SHA-256 (path -> hex digest) and reports every
+// missing or mismatching file, in path order
+func FilesChecksumCondition(checksums map[string]string) Condition {
+	paths := make([]string, 0, len(checksums))
+	for path := range checksums {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	return newDetailedCondition("files-checksum", fmt.Sprintf("%d files match their expected SHA-256", len(paths)), func() (bool, string, error) {
+		var problems []string
+		for _, path := range paths {
+			ok, detail, err := verifyChecksum(path, checksums[path])
+			if err != nil {
+				return false, strings.Join(problems, "; "), err
+			}
+			if !ok {
+				problems = append(problems, detail)
+			}
+		}
+		if len(problems) > 0 {
+			return false, strings.Join(problems, "; "), nil
+		}
+		return true, fmt.Sprintf("%d files verified", len(paths)), nil
+	})
+}
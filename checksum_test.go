@@ -0,0 +1,67 @@
+package release
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// sha256 of "hello\n"
+const helloSHA256 = "5891b5b522d5df086d0ff0b110fbd9d21bb4fc7163af34d08286a2e846f6be03"
+
+func writeTempFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestFileChecksumCondition(t *testing.T) {
+	path := writeTempFile(t, "data.txt", "hello\n")
+
+	if r := FileChecksumCondition(path, helloSHA256).evaluate(); !r.Passed || r.Error != nil {
+		t.Errorf("Matching checksum: got %+v", r)
+	}
+	if r := FileChecksumCondition(path, strings.ToUpper(helloSHA256)).evaluate(); !r.Passed {
+		t.Errorf("Checksum comparison should be case-insensitive: got %+v", r)
+	}
+
+	r := FileChecksumCondition(path, strings.Repeat("0", 64)).evaluate()
+	if r.Passed || r.Error != nil || !strings.Contains(r.Detail, "mismatch") {
+		t.Errorf("Mismatching checksum: got %+v", r)
+	}
+
+	r = FileChecksumCondition(filepath.Join(t.TempDir(), "missing"), helloSHA256).evaluate()
+	if r.Passed || r.Error != nil || !strings.Contains(r.Detail, "not found") {
+		t.Errorf("Missing file: got %+v", r)
+	}
+}
+
+func TestFilesChecksumCondition(t *testing.T) {
+	good := writeTempFile(t, "good.txt", "hello\n")
+	bad := writeTempFile(t, "bad.txt", "tampered\n")
+	missing := filepath.Join(t.TempDir(), "missing.txt")
+
+	r := FilesChecksumCondition(map[string]string{
+		good:    helloSHA256,
+		bad:     helloSHA256,
+		missing: helloSHA256,
+	}).evaluate()
+
+	if r.Passed {
+		t.Fatal("Batch with a mismatch and a missing file should fail")
+	}
+	if !strings.Contains(r.Detail, bad+": checksum mismatch") || !strings.Contains(r.Detail, missing+": file not found") {
+		t.Errorf("Detail should report each problem: %q", r.Detail)
+	}
+	if strings.Contains(r.Detail, good) {
+		t.Errorf("Detail should not mention the verified file: %q", r.Detail)
+	}
+
+	if r := FilesChecksumCondition(map[string]string{good: helloSHA256}).evaluate(); !r.Passed {
+		t.Errorf("All matching files should pass: %+v", r)
+	}
+}
@@ -0,0 +1,39 @@
+package release
+
+import (
+	"fmt"
+	"time"
+)
+
+// clockStatus is the kernel's view of clock synchronization
+type clockStatus struct {
+	synced   bool
+	maxError time.Duration
+	estError time.Duration
+}
+
+// IsClockSynchronized reports whether the kernel considers the system
+// clock synchronized by NTP (or another time discipline daemon), as
+// reported by adjtimex. It returns ErrNotSupported on non-Linux platforms
+func IsClockSynchronized() (bool, error) {
+	status, err := clockSyncStatus()
+	if err != nil {
+		return false, err
+	}
+	return status.synced, nil
+}
+
+// ClockSyncCondition returns a condition that fails when the system clock
+// is not synchronized, reporting the kernel's error estimates
+func ClockSyncCondition() Condition {
+	return newDetailedCondition("clock-sync", "System clock is synchronized", func() (bool, string, error) {
+		status, err := clockSyncStatus()
+		if err != nil {
+			return false, "", err
+		}
+		if !status.synced {
+			return false, fmt.Sprintf("clock is not synchronized (max error %s)", status.maxError), nil
+		}
+		return true, fmt.Sprintf("clock is synchronized (max error %s, estimated error %s)", status.maxError, status.estError), nil
+	})
+}
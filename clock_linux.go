@@ -0,0 +1,26 @@
+package release
+
+import (
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+func clockSyncStatus() (clockStatus, error) {
+	// Modes == 0 only reads the kernel's state and needs no privileges
+	var tx unix.Timex
+	state, err := unix.Adjtimex(&tx)
+	if err != nil {
+		return clockStatus{}, err
+	}
+	return clockStatus{
+		synced:   clockSynced(state, int64(tx.Status)),
+		maxError: time.Duration(tx.Maxerror) * time.Microsecond,
+		estError: time.Duration(tx.Esterror) * time.Microsecond,
+	}, nil
+}
+
+// clockSynced interprets the adjtimex state and status flags
+func clockSynced(state int, status int64) bool {
+	return state != unix.TIME_ERROR && status&unix.STA_UNSYNC == 0
+}
@@ -0,0 +1,26 @@
+package release
+
+import (
+	"testing"
+
+	"golang.org/x/sys/unix"
+)
+
+func TestClockSynced(t *testing.T) {
+	tests := []struct {
+		state  int
+		status int64
+		want   bool
+	}{
+		{unix.TIME_OK, 0, true},
+		{unix.TIME_OK, unix.STA_PLL, true},
+		{unix.TIME_ERROR, 0, false},
+		{unix.TIME_OK, unix.STA_UNSYNC, false},
+		{unix.TIME_ERROR, unix.STA_UNSYNC, false},
+	}
+	for _, tt := range tests {
+		if got := clockSynced(tt.state, tt.status); got != tt.want {
+			t.Errorf("clockSynced(%d, %#x) = %v, want %v", tt.state, tt.status, got, tt.want)
+		}
+	}
+}
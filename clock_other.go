@@ -0,0 +1,7 @@
+//go:build !linux
+
+package release
+
+func clockSyncStatus() (clockStatus, error) {
+	return clockStatus{}, ErrNotSupported
+}
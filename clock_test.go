@@ -0,0 +1,26 @@
+package release
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestClockSyncCondition(t *testing.T) {
+	synced, err := IsClockSynchronized()
+	if errors.Is(err, ErrNotSupported) {
+		if r := ClockSyncCondition().evaluate(); !errors.Is(r.Error, ErrNotSupported) {
+			t.Errorf("Expected ErrNotSupported, got %+v", r)
+		}
+		return
+	}
+	if err != nil {
+		t.Skipf("cannot read clock state: %v", err)
+	}
+	t.Logf("Clock synchronized: %v", synced)
+
+	r := ClockSyncCondition().evaluate()
+	if r.Passed != synced || !strings.Contains(r.Detail, "max error") {
+		t.Errorf("ClockSyncCondition() = %+v, IsClockSynchronized() = %v", r, synced)
+	}
+}
@@ -0,0 +1,100 @@
+// Command release-check runs the release conditions declared in a YAML
+// spec, prints a report and exits non-zero if any condition fails
+//
+// Usage:
+//
+//	release-check [-spec release-check.yaml] [-format table|json|markdown|junit] [-tag key[=value]]...
+//
+// Each -tag keeps only the conditions carrying that label (any value when
+// none is given), e.g. -tag network or -tag stage=deploy. Repeated tags
+// must all match
+//
+// Exit codes: 0 when all conditions pass, 1 when any fails, 2 on usage
+// or spec errors. A failed condition with an exit_code in the spec exits
+// with that code instead; the first such condition in the spec wins
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	release "github.com/parthban-db/test-go-release"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run executes the command and returns its exit code
+func run(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("release-check", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	specPath := fs.String("spec", "release-check.yaml", "path to the YAML condition spec")
+	format := fs.String("format", "table", "report format: table, json, markdown or junit")
+	var tags []string
+	fs.Func("tag", "only run conditions with this label, as key or key=value (repeatable)", func(tag string) error {
+		tags = append(tags, tag)
+		return nil
+	})
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	write, ok := reportWriters[*format]
+	if !ok {
+		fmt.Fprintf(stderr, "release-check: unknown format %q\n", *format)
+		return 2
+	}
+
+	spec, err := LoadSpec(*specPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "release-check: %v\n", err)
+		return 2
+	}
+	cs, err := spec.ConditionSet()
+	if err != nil {
+		fmt.Fprintf(stderr, "release-check: %v\n", err)
+		return 2
+	}
+
+	for _, tag := range tags {
+		key, value, _ := strings.Cut(tag, "=")
+		cs = cs.FilterByLabel(key, value)
+	}
+	if len(tags) > 0 && cs.Len() == 0 {
+		fmt.Fprintf(stderr, "release-check: no conditions match tags %v\n", tags)
+		return 2
+	}
+
+	results := cs.TestAll()
+	if err := write(results, stdout); err != nil {
+		fmt.Fprintf(stderr, "release-check: writing report: %v\n", err)
+		return 2
+	}
+
+	return results.ExitCode()
+}
+
+// reportWriters maps each -format value to the function that writes the
+// report in that format
+var reportWriters = map[string]func(release.TestResults, io.Writer) error{
+	"table": func(results release.TestResults, w io.Writer) error {
+		return results.WriteTable(w, release.ReportOptions{})
+	},
+	"json": func(results release.TestResults, w io.Writer) error {
+		return results.WriteJSON(w)
+	},
+	"markdown": func(results release.TestResults, w io.Writer) error {
+		return results.WriteMarkdown(w, release.ReportOptions{})
+	},
+	"junit": func(results release.TestResults, w io.Writer) error {
+		data, err := results.ToJUnit("release-check")
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(data)
+		return err
+	},
+}
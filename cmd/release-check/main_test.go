@@ -0,0 +1,235 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func writeSpec(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "release-check.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestParseSpec(t *testing.T) {
+	spec, err := ParseSpec(strings.NewReader(`
+conditions:
+  - type: go-version
+    version: "1.10"
+  - type: tcp-reachable
+    name: db
+    addr: localhost:5432
+    timeout: 500ms
+    advisory: true
+`))
+	if err != nil {
+		t.Fatalf("ParseSpec() error = %v", err)
+	}
+	if len(spec.Conditions) != 2 {
+		t.Fatalf("Expected 2 conditions, got %d", len(spec.Conditions))
+	}
+	if got := spec.Conditions[1].Timeout.String(); got != "500ms" {
+		t.Errorf("Timeout = %s, want 500ms", got)
+	}
+
+	cs, err := spec.ConditionSet()
+	if err != nil {
+		t.Fatalf("ConditionSet() error = %v", err)
+	}
+	cond, ok := cs.Get("db")
+	if !ok || !cond.ErrorIsPass {
+		t.Errorf("Expected an advisory condition named db, got %+v, %v", cond, ok)
+	}
+}
+
+func TestParseSpecOctal(t *testing.T) {
+	spec, err := ParseSpec(strings.NewReader("conditions:\n  - type: max-umask\n    min: 0o027\n"))
+	if err != nil {
+		t.Fatalf("ParseSpec() error = %v", err)
+	}
+	if got := spec.Conditions[0].Min; got != 0o027 {
+		t.Errorf("Min = %o, want 27 octal", got)
+	}
+}
+
+func TestSpecErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		spec string
+	}{
+		{"unknown field", "conditions:\n  - type: os\n    valuez: [linux]\n"},
+		{"unknown type", "conditions:\n  - type: nope\n"},
+		{"missing param", "conditions:\n  - type: go-version\n"},
+		{"reserved exit code", "conditions:\n  - type: unix\n    exit_code: 2\n"},
+		{"bad platform", "conditions:\n  - type: platform\n    values: [linux]\n"},
+		{"min out of range", "conditions:\n  - type: min-cpus\n    min: 18446744073709551615\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec, err := ParseSpec(strings.NewReader(tt.spec))
+			if err == nil {
+				_, err = spec.ConditionSet()
+			}
+			if err == nil {
+				t.Error("Expected an error")
+			}
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	passing := writeSpec(t, `
+conditions:
+  - type: go-version
+    version: "1.10"
+  - type: platform
+    values: [`+runtime.GOOS+`/`+runtime.GOARCH+`]
+`)
+	failing := writeSpec(t, `
+conditions:
+  - type: os
+    values: [fakeos]
+`)
+
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"-spec", passing}, &stdout, &stderr); code != 0 {
+		t.Errorf("Passing spec exited %d: %s%s", code, stdout.String(), stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "2/2 conditions passed") {
+		t.Errorf("Unexpected table output:\n%s", stdout.String())
+	}
+
+	stdout.Reset()
+	if code := run([]string{"-spec", failing, "-format", "json"}, &stdout, &stderr); code != 1 {
+		t.Errorf("Failing spec exited %d, want 1", code)
+	}
+	var report map[string]any
+	if err := json.Unmarshal(stdout.Bytes(), &report); err != nil || report["all_passed"] != false {
+		t.Errorf("Unexpected JSON output (%v):\n%s", err, stdout.String())
+	}
+
+	stdout.Reset()
+	if code := run([]string{"-spec", passing, "-format", "markdown"}, &stdout, &stderr); code != 0 || !strings.Contains(stdout.String(), "| Status |") {
+		t.Errorf("Markdown run exited %d:\n%s", code, stdout.String())
+	}
+
+	stdout.Reset()
+	if code := run([]string{"-spec", failing, "-format", "junit"}, &stdout, &stderr); code != 1 || !strings.Contains(stdout.String(), "<failure") {
+		t.Errorf("JUnit run exited %d:\n%s", code, stdout.String())
+	}
+
+	if code := run([]string{"-spec", passing, "-format", "xml"}, &stdout, &stderr); code != 2 {
+		t.Errorf("Unknown format exited %d, want 2", code)
+	}
+	if code := run([]string{"-spec", filepath.Join(t.TempDir(), "missing.yaml")}, &stdout, &stderr); code != 2 {
+		t.Errorf("Missing spec exited %d, want 2", code)
+	}
+}
+
+func TestRunUnknownFormatSkipsProbes(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	var dials atomic.Int32
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			dials.Add(1)
+			conn.Close()
+		}
+	}()
+	spec := writeSpec(t, `
+conditions:
+  - type: tcp-reachable
+    addr: `+ln.Addr().String()+`
+`)
+
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"-spec", spec, "-format", "xml"}, &stdout, &stderr); code != 2 {
+		t.Errorf("Unknown format exited %d, want 2", code)
+	}
+	if !strings.Contains(stderr.String(), `unknown format "xml"`) {
+		t.Errorf("Unexpected error output: %s", stderr.String())
+	}
+	if stdout.Len() != 0 {
+		t.Errorf("Unknown format wrote a report:\n%s", stdout.String())
+	}
+
+	// A passing run dials the listener, so a zero count after it means the
+	// bad format above never reached the probe
+	before := dials.Load()
+	if code := run([]string{"-spec", spec}, &stdout, &stderr); code != 0 {
+		t.Fatalf("Valid format exited %d: %s%s", code, stdout.String(), stderr.String())
+	}
+	if before != 0 {
+		t.Errorf("Unknown format ran the probe %d times, want 0", before)
+	}
+}
+
+func TestRunExitCode(t *testing.T) {
+	spec := writeSpec(t, `
+conditions:
+  - type: go-version
+    version: "1.10"
+    exit_code: 10
+  - type: os
+    values: [fakeos]
+  - type: arch
+    values: [fakearch]
+    exit_code: 11
+`)
+
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"-spec", spec}, &stdout, &stderr); code != 11 {
+		t.Errorf("Spec with a coded failure exited %d, want 11: %s", code, stderr.String())
+	}
+}
+
+func TestRunTagFilter(t *testing.T) {
+	spec := writeSpec(t, `
+conditions:
+  - type: go-version
+    version: "1.10"
+    tags: [build]
+    labels:
+      stage: deploy
+  - type: os
+    values: [fakeos]
+    tags: [platform]
+`)
+
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"-spec", spec, "-tag", "build"}, &stdout, &stderr); code != 0 {
+		t.Errorf("-tag build exited %d: %s%s", code, stdout.String(), stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "1/1 conditions passed") {
+		t.Errorf("Unexpected table output:\n%s", stdout.String())
+	}
+
+	stdout.Reset()
+	if code := run([]string{"-spec", spec, "-tag", "build", "-tag", "stage=deploy"}, &stdout, &stderr); code != 0 {
+		t.Errorf("-tag build -tag stage=deploy exited %d: %s", code, stderr.String())
+	}
+	if code := run([]string{"-spec", spec, "-tag", "platform"}, &stdout, &stderr); code != 1 {
+		t.Errorf("-tag platform exited %d, want 1", code)
+	}
+	if code := run([]string{"-spec", spec, "-tag", "stage=test"}, &stdout, &stderr); code != 2 {
+		t.Errorf("-tag with no matches exited %d, want 2", code)
+	}
+}
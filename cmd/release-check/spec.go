@@ -0,0 +1,368 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"time"
+
+	release "github.com/parthban-db/test-go-release"
+	"gopkg.in/yaml.v3"
+)
+
+// Spec is a declarative list of release conditions
+//
+//	conditions:
+//	  - type: go-version
+//	    version: "1.21"
+//	  - type: os
+//	    values: [linux, darwin]
+//	  - type: tcp-reachable
+//	    name: db
+//	    addr: db.internal:5432
+//	    timeout: 2s
+//	    advisory: true
+//	    exit_code: 12
+//	    tags: [network]
+//	    labels:
+//	      stage: deploy
+type Spec struct {
+	Conditions []ConditionSpec `yaml:"conditions"`
+}
+
+// ConditionSpec declares a single condition. Type selects the condition;
+// the other parameters are used by the types that need them
+type ConditionSpec struct {
+	Type        string        `yaml:"type"`
+	Name        string        `yaml:"name"`
+	Description string        `yaml:"description"`
+	Advisory    bool          `yaml:"advisory"`
+	Version     string        `yaml:"version"`
+	Values      []string      `yaml:"values"`
+	Addr        string        `yaml:"addr"`
+	Addrs       []string      `yaml:"addrs"`
+	Path        string        `yaml:"path"`
+	SHA256      string        `yaml:"sha256"`
+	Min         uint64        `yaml:"min"`
+	Port        int           `yaml:"port"`
+	Timeout     time.Duration `yaml:"timeout"`
+	MaxAge      time.Duration `yaml:"max_age"`
+
+	// ExitCode is the exit status when this condition fails, letting
+	// wrapper scripts tell failures apart. 2 is reserved for usage errors
+	ExitCode int `yaml:"exit_code"`
+
+	// Labels are attached to the condition as is. Tags are shorthand for
+	// labels with an empty value, selected with -tag name
+	Labels map[string]string `yaml:"labels"`
+	Tags   []string          `yaml:"tags"`
+}
+
+// defaultTimeout is used by network and entropy checks without a timeout
+const defaultTimeout = 2 * time.Second
+
+// builders maps each condition type to its constructor
+var builders = map[string]func(ConditionSpec) (release.Condition, error){
+	"go-version": func(s ConditionSpec) (release.Condition, error) {
+		if s.Version == "" {
+			return release.Condition{}, errors.New("version is required")
+		}
+		return release.Condition{
+			Name:        "go-version",
+			Description: fmt.Sprintf("Go version >= %s", s.Version),
+			Check: func() (bool, error) {
+				return release.IsGoVersionAtLeast(s.Version)
+			},
+		}, nil
+	},
+	"go-minor-line": func(s ConditionSpec) (release.Condition, error) {
+		if s.Version == "" {
+			return release.Condition{}, errors.New("version is required")
+		}
+		return release.Condition{
+			Name:        "go-minor-line",
+			Description: fmt.Sprintf("Go version is on the %s minor line", s.Version),
+			Check: func() (bool, error) {
+				return release.SameMinorLine(s.Version)
+			},
+		}, nil
+	},
+	"os": func(s ConditionSpec) (release.Condition, error) {
+		return anyOf("os", "OS", s.Values, release.IsOS)
+	},
+	"arch": func(s ConditionSpec) (release.Condition, error) {
+		return anyOf("arch", "Architecture", s.Values, release.IsArch)
+	},
+	"platform": func(s ConditionSpec) (release.Condition, error) {
+		for _, v := range s.Values {
+			if _, err := release.ParsePlatform(v); err != nil {
+				return release.Condition{}, err
+			}
+		}
+		return anyOf("platform", "Platform", s.Values, func(v string) bool {
+			p, _ := release.ParsePlatform(v)
+			return release.IsPlatformP(p)
+		})
+	},
+	"unix": func(s ConditionSpec) (release.Condition, error) {
+		return release.Condition{
+			Name:        "unix",
+			Description: "Running on a Unix-like OS",
+			Check: func() (bool, error) {
+				return release.IsUnix(), nil
+			},
+		}, nil
+	},
+	"native-execution":           noParams(release.NativeExecutionCondition),
+	"not-go-run":                 noParams(release.NotGoRunCondition),
+	"gc-enabled":                 noParams(release.GCEnabledCondition),
+	"gc-enabled-or-memory-limit": noParams(release.GCEnabledOrMemoryLimitCondition),
+	"vcs-info":                   noParams(release.RequireVCSInfoCondition),
+	"tagged-build":               noParams(release.TaggedBuildCondition),
+	"utf8-locale":                noParams(release.UTF8LocaleCondition),
+	"resolv-conf":                noParams(release.ResolvConfCondition),
+	"stripped-binary":            noParams(release.StrippedBinaryCondition),
+	"supported-go-version":       noParams(release.SupportedGoVersionCondition),
+	"no-pprof":                   noParams(release.NoPprofCondition),
+	"clock-sync":                 noParams(release.ClockSyncCondition),
+	"no-local-replace":           noParams(release.NoLocalReplaceCondition),
+	"no-duplicate-modules":       noParams(release.NoDuplicateModulesCondition),
+	"module-build":               noParams(release.ModuleBuildCondition),
+	"secure-toolchain":           noParams(release.SecureToolchainCondition),
+	"std-streams":                noParams(release.StdStreamsCondition),
+	"crypto-rand": func(s ConditionSpec) (release.Condition, error) {
+		return release.CryptoRandAvailableCondition(s.timeout()), nil
+	},
+	"tcp-reachable": func(s ConditionSpec) (release.Condition, error) {
+		if s.Addr == "" {
+			return release.Condition{}, errors.New("addr is required")
+		}
+		return release.TCPReachableCondition(s.Addr, s.timeout()), nil
+	},
+	"any-reachable": func(s ConditionSpec) (release.Condition, error) {
+		if len(s.Addrs) == 0 {
+			return release.Condition{}, errors.New("addrs is required")
+		}
+		return release.AnyReachableCondition(s.Addrs, s.timeout()), nil
+	},
+	"file-checksum": func(s ConditionSpec) (release.Condition, error) {
+		if s.Path == "" || s.SHA256 == "" {
+			return release.Condition{}, errors.New("path and sha256 are required")
+		}
+		return release.FileChecksumCondition(s.Path, s.SHA256), nil
+	},
+	"min-stack-size": func(s ConditionSpec) (release.Condition, error) {
+		return release.MinStackSizeCondition(s.Min), nil
+	},
+	"min-ephemeral-ports": func(s ConditionSpec) (release.Condition, error) {
+		n, err := s.minInt()
+		if err != nil {
+			return release.Condition{}, err
+		}
+		return release.MinEphemeralPortsCondition(n), nil
+	},
+	"min-inodes": func(s ConditionSpec) (release.Condition, error) {
+		if s.Path == "" {
+			return release.Condition{}, errors.New("path is required")
+		}
+		return release.MinInodesCondition(s.Path, s.Min), nil
+	},
+	"memlock-capable": func(s ConditionSpec) (release.Condition, error) {
+		return release.MemlockCapableCondition(s.Min), nil
+	},
+	"min-cpus": func(s ConditionSpec) (release.Condition, error) {
+		n, err := s.minInt()
+		if err != nil {
+			return release.Condition{}, err
+		}
+		return release.MinCPUsCondition(n), nil
+	},
+	"min-swap": func(s ConditionSpec) (release.Condition, error) {
+		return release.MinSwapCondition(s.Min), nil
+	},
+	"ca-certs": func(s ConditionSpec) (release.Condition, error) {
+		n, err := s.minInt()
+		if err != nil {
+			return release.Condition{}, err
+		}
+		return release.CACertsCondition(n), nil
+	},
+	"port-bindable": func(s ConditionSpec) (release.Condition, error) {
+		return release.PortBindableCondition(s.Port), nil
+	},
+	"min-network-interfaces": func(s ConditionSpec) (release.Condition, error) {
+		n, err := s.minInt()
+		if err != nil {
+			return release.Condition{}, err
+		}
+		return release.MinNetworkInterfacesCondition(n), nil
+	},
+	"pid-headroom": func(s ConditionSpec) (release.Condition, error) {
+		return release.PidHeadroomCondition(s.Min), nil
+	},
+	"min-address-space": func(s ConditionSpec) (release.Condition, error) {
+		return release.MinAddressSpaceCondition(s.Min), nil
+	},
+	"page-size": func(s ConditionSpec) (release.Condition, error) {
+		if s.Min == 0 {
+			return release.Condition{}, errors.New("min is required")
+		}
+		n, err := s.minInt()
+		if err != nil {
+			return release.Condition{}, err
+		}
+		return release.PageSizeCondition(n), nil
+	},
+	"max-umask": func(s ConditionSpec) (release.Condition, error) {
+		n, err := s.minInt()
+		if err != nil {
+			return release.Condition{}, err
+		}
+		return release.MaxUmaskCondition(n), nil
+	},
+	"forbidden-godebug": func(s ConditionSpec) (release.Condition, error) {
+		if len(s.Values) == 0 {
+			return release.Condition{}, errors.New("values is required")
+		}
+		return release.ForbiddenGoDebugCondition(s.Values...), nil
+	},
+	"log-dir-writable": func(s ConditionSpec) (release.Condition, error) {
+		if s.Path == "" {
+			return release.Condition{}, errors.New("path is required")
+		}
+		return release.LogDirWritableCondition(s.Path), nil
+	},
+	"min-platform-tier": func(s ConditionSpec) (release.Condition, error) {
+		if s.Min == 0 {
+			return release.Condition{}, errors.New("min is required")
+		}
+		n, err := s.minInt()
+		if err != nil {
+			return release.Condition{}, err
+		}
+		return release.MinPlatformTierCondition(n), nil
+	},
+	"max-binary-age": func(s ConditionSpec) (release.Condition, error) {
+		if s.MaxAge <= 0 {
+			return release.Condition{}, errors.New("max_age is required")
+		}
+		return release.MaxBinaryAgeCondition(s.MaxAge), nil
+	},
+	"required-capabilities": func(s ConditionSpec) (release.Condition, error) {
+		if len(s.Values) == 0 {
+			return release.Condition{}, errors.New("values is required")
+		}
+		return release.RequiredCapabilitiesCondition(s.Values...), nil
+	},
+	"required-shared-libs": func(s ConditionSpec) (release.Condition, error) {
+		if len(s.Values) == 0 {
+			return release.Condition{}, errors.New("values is required")
+		}
+		return release.RequiredSharedLibsCondition(s.Values...), nil
+	},
+}
+
+// noParams adapts a parameterless condition constructor
+func noParams(fn func() release.Condition) func(ConditionSpec) (release.Condition, error) {
+	return func(ConditionSpec) (release.Condition, error) {
+		return fn(), nil
+	}
+}
+
+// anyOf builds a condition passing when match accepts any of values
+func anyOf(name, label string, values []string, match func(string) bool) (release.Condition, error) {
+	if len(values) == 0 {
+		return release.Condition{}, errors.New("values is required")
+	}
+	return release.Condition{
+		Name:        name,
+		Description: fmt.Sprintf("%s is one of %v", label, values),
+		Check: func() (bool, error) {
+			for _, v := range values {
+				if match(v) {
+					return true, nil
+				}
+			}
+			return false, nil
+		},
+	}, nil
+}
+
+func (s ConditionSpec) timeout() time.Duration {
+	if s.Timeout <= 0 {
+		return defaultTimeout
+	}
+	return s.Timeout
+}
+
+// minInt returns Min for the conditions that take an int, rejecting values
+// that do not fit rather than truncating them
+func (s ConditionSpec) minInt() (int, error) {
+	if s.Min > math.MaxInt {
+		return 0, fmt.Errorf("min %d is out of range", s.Min)
+	}
+	return int(s.Min), nil
+}
+
+// LoadSpec reads a YAML spec from path
+func LoadSpec(path string) (*Spec, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ParseSpec(f)
+}
+
+// ParseSpec decodes a YAML spec, rejecting unknown fields
+func ParseSpec(r io.Reader) (*Spec, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var spec Spec
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&spec); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("parsing spec: %w", err)
+	}
+	return &spec, nil
+}
+
+// ConditionSet builds the condition set declared by the spec
+func (spec *Spec) ConditionSet() (*release.ConditionSet, error) {
+	cs := release.NewConditionSet()
+	for i, s := range spec.Conditions {
+		build, ok := builders[s.Type]
+		if !ok {
+			return nil, fmt.Errorf("condition %d: unknown type %q", i+1, s.Type)
+		}
+		cond, err := build(s)
+		if err != nil {
+			return nil, fmt.Errorf("condition %d (%s): %w", i+1, s.Type, err)
+		}
+		if s.Name != "" {
+			cond.Name = s.Name
+		}
+		if s.Description != "" {
+			cond.Description = s.Description
+		}
+		cond.ErrorIsPass = s.Advisory
+		if s.ExitCode < 0 || s.ExitCode == 2 || s.ExitCode > 125 {
+			return nil, fmt.Errorf("condition %d (%s): exit_code must be 1 or 3-125, got %d", i+1, s.Type, s.ExitCode)
+		}
+		cond.ExitCode = s.ExitCode
+		for key, value := range s.Labels {
+			cond = cond.WithLabel(key, value)
+		}
+		for _, tag := range s.Tags {
+			cond = cond.WithLabel(tag, "")
+		}
+		cs.AddAll(cond)
+	}
+	return cs, nil
+}
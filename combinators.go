@@ -0,0 +1,46 @@
+package release
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// AtLeast returns a condition that passes when at least n of the given
+// conditions pass (see TestResult.OK). All conditions are evaluated, in
+// order, and the detail lists the ones that failed
+func AtLeast(n int, conditions ...Condition) Condition {
+	name := fmt.Sprintf("at-least-%d-of-%d", n, len(conditions))
+	description := fmt.Sprintf("At least %d of %d conditions pass", n, len(conditions))
+
+	return newContextCondition(name, description, func(ctx context.Context) (bool, string, error) {
+		passed := 0
+		var failures []string
+		for _, cond := range conditions {
+			r := cond.evaluateIn(ctx)
+			if r.OK() {
+				passed++
+				continue
+			}
+			failures = append(failures, describeFailure(r))
+		}
+
+		detail := fmt.Sprintf("%d/%d passed", passed, len(conditions))
+		if len(failures) > 0 {
+			detail += "; failed: " + strings.Join(failures, ", ")
+		}
+		return passed >= n, detail, nil
+	})
+}
+
+// describeFailure summarizes a failed result as "name (reason)"
+func describeFailure(r TestResult) string {
+	switch {
+	case r.Error != nil:
+		return fmt.Sprintf("%s (%v)", r.Name, r.Error)
+	case r.Detail != "":
+		return fmt.Sprintf("%s (%s)", r.Name, r.Detail)
+	default:
+		return r.Name
+	}
+}
@@ -0,0 +1,44 @@
+package release
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func staticCondition(name string, passed bool, err error) Condition {
+	return Condition{
+		Name: name,
+		Check: func() (bool, error) {
+			return passed, err
+		},
+	}
+}
+
+func TestAtLeast(t *testing.T) {
+	conds := []Condition{
+		staticCondition("a", true, nil),
+		staticCondition("b", false, nil),
+		staticCondition("c", true, errors.New("boom")),
+	}
+
+	tests := []struct {
+		n    int
+		want bool
+	}{
+		{0, true},
+		{1, true},
+		{2, false},
+		{3, false},
+	}
+
+	for _, tt := range tests {
+		result := AtLeast(tt.n, conds...).evaluate()
+		if result.Passed != tt.want {
+			t.Errorf("AtLeast(%d) = %v, want %v", tt.n, result.Passed, tt.want)
+		}
+		if !strings.Contains(result.Detail, "1/3 passed") || !strings.Contains(result.Detail, "c (boom)") {
+			t.Errorf("Unexpected detail: %q", result.Detail)
+		}
+	}
+}
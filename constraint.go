@@ -0,0 +1,85 @@
+package release
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+// constraintOperators lists the operators of a version constraint, with
+// two-character operators first so ">=" is not read as ">"
+var constraintOperators = []string{">=", "<=", "!=", ">", "<", "="}
+
+// SatisfiesConstraint checks the current Go version against a constraint
+// such as ">=1.20,<1.23". The constraint is a comma-separated list of
+// operator and version pairs, all of which must hold; the operators are
+// >=, >, <=, <, = and !=. Versions accept the same formats as
+// CompareGoVersion. A malformed constraint returns an error naming the
+// offending part
+func SatisfiesConstraint(constraint string) (bool, error) {
+	return satisfiesConstraint(runtime.Version(), constraint)
+}
+
+// satisfiesConstraint checks current against constraint. Every part is
+// validated, even after one fails to hold
+func satisfiesConstraint(current, constraint string) (bool, error) {
+	if strings.TrimSpace(constraint) == "" {
+		return false, errors.New("empty version constraint")
+	}
+	cur, err := ParseGoVersion(current)
+	if err != nil {
+		return false, fmt.Errorf("invalid current version: %w", err)
+	}
+
+	satisfied := true
+	for _, part := range strings.Split(constraint, ",") {
+		op, version, err := parseConstraintPart(strings.TrimSpace(part))
+		if err != nil {
+			return false, err
+		}
+		if !constraintHolds(op, Ordering(cur.Compare(version))) {
+			satisfied = false
+		}
+	}
+	return satisfied, nil
+}
+
+// parseConstraintPart splits a constraint part such as ">=1.20" into its
+// operator and version
+func parseConstraintPart(part string) (string, GoVersion, error) {
+	var op, version string
+	for _, candidate := range constraintOperators {
+		if rest, ok := strings.CutPrefix(part, candidate); ok {
+			op, version = candidate, strings.TrimSpace(rest)
+			break
+		}
+	}
+	if op == "" {
+		return "", GoVersion{}, fmt.Errorf("invalid constraint %q: missing operator, want one of %s", part, strings.Join(constraintOperators, " "))
+	}
+	v, err := ParseGoVersion(version)
+	if err != nil {
+		return "", GoVersion{}, fmt.Errorf("invalid constraint %q: %w", part, err)
+	}
+	return op, v, nil
+}
+
+// constraintHolds applies op to the result of comparing the current
+// version with the constraint's version
+func constraintHolds(op string, cmp Ordering) bool {
+	switch op {
+	case ">=":
+		return !cmp.IsLess()
+	case "<=":
+		return !cmp.IsGreater()
+	case "!=":
+		return !cmp.IsEqual()
+	case ">":
+		return cmp.IsGreater()
+	case "<":
+		return cmp.IsLess()
+	default:
+		return cmp.IsEqual()
+	}
+}
@@ -0,0 +1,68 @@
+package release
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSatisfiesConstraint(t *testing.T) {
+	tests := []struct {
+		current    string
+		constraint string
+		want       bool
+	}{
+		{"go1.21.5", ">=1.20,<1.23", true},
+		{"go1.23.0", ">=1.20,<1.23", false},
+		{"go1.19.13", ">=1.20,<1.23", false},
+		{"go1.21.5", ">= 1.20 , < go1.23", true},
+		{"go1.21.5", ">1.21.4", true},
+		{"go1.21.4", ">1.21.4", false},
+		{"go1.21.4", "<=v1.21.4", true},
+		{"go1.21.0", "=1.21", true},
+		{"go1.21.1", "=1.21", false},
+		{"go1.21.1", "!=1.21.0", true},
+		{"go1.21.0", ">=1.20,!=1.21.0", false},
+		{"go1.22rc1", ">=1.22", false},
+		{"go1.22rc1", ">=1.22rc1", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.current+" "+tt.constraint, func(t *testing.T) {
+			got, err := satisfiesConstraint(tt.current, tt.constraint)
+			if err != nil {
+				t.Fatalf("satisfiesConstraint() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("satisfiesConstraint(%q, %q) = %v, want %v", tt.current, tt.constraint, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSatisfiesConstraintErrors(t *testing.T) {
+	tests := []struct {
+		constraint string
+		wantErr    string
+	}{
+		{"", "empty version constraint"},
+		{"1.20", `"1.20": missing operator`},
+		{"~>1.20", `"~>1.20": missing operator`},
+		{"==1.20", `invalid Go version "=1.20"`},
+		{">=1.20,<", `invalid Go version ""`},
+		{">=1.20,", `invalid constraint "": missing operator`},
+		{"<1.19,>=latest", `invalid Go version "latest"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.constraint, func(t *testing.T) {
+			_, err := satisfiesConstraint("go1.21.0", tt.constraint)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("satisfiesConstraint(%q) error = %v, want it to contain %q", tt.constraint, err, tt.wantErr)
+			}
+		})
+	}
+
+	if ok, err := SatisfiesConstraint(">=1.10,<99"); !ok || err != nil {
+		t.Errorf("SatisfiesConstraint() = %v, %v; want true", ok, err)
+	}
+}
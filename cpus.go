@@ -0,0 +1,40 @@
+package release
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// AllowedCPUs returns the number of CPUs the process may currently run
+// on. On Linux it counts the CPU affinity mask (sched_getaffinity), which
+// can be narrower than NumCPU, e.g. under taskset or cpusets, and
+// reflects changes made after startup. Elsewhere, or when the mask cannot
+// be read (e.g. EINVAL on hosts with more than 1024 CPUs), it returns
+// runtime.NumCPU
+func AllowedCPUs() (int, error) {
+	cpus, _ := cpuCount()
+	return cpus, nil
+}
+
+// cpuCount returns the CPU count of AllowedCPUs and, when the affinity
+// mask could not be read, the reason it fell back to runtime.NumCPU
+func cpuCount() (int, string) {
+	cpus, err := allowedCPUs()
+	if err != nil {
+		return runtime.NumCPU(), fmt.Sprintf("affinity mask unavailable (%v), using NumCPU", err)
+	}
+	return cpus, ""
+}
+
+// MinCPUsCondition returns a condition that fails when the process may
+// run on fewer than n CPUs, as reported by AllowedCPUs
+func MinCPUsCondition(n int) Condition {
+	return newDetailedCondition("min-cpus", fmt.Sprintf("At least %d CPUs available", n), func() (bool, string, error) {
+		cpus, fallback := cpuCount()
+		detail := fmt.Sprintf("found %d CPU(s), need %d", cpus, n)
+		if fallback != "" {
+			detail += "; " + fallback
+		}
+		return cpus >= n, detail, nil
+	})
+}
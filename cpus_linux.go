@@ -0,0 +1,14 @@
+package release
+
+import "golang.org/x/sys/unix"
+
+// schedGetaffinity reads the CPU affinity mask; tests replace it
+var schedGetaffinity = unix.SchedGetaffinity
+
+func allowedCPUs() (int, error) {
+	var set unix.CPUSet
+	if err := schedGetaffinity(0, &set); err != nil {
+		return 0, err
+	}
+	return set.Count(), nil
+}
@@ -0,0 +1,28 @@
+package release
+
+import (
+	"runtime"
+	"strings"
+	"testing"
+
+	"golang.org/x/sys/unix"
+)
+
+func TestAllowedCPUsAffinityError(t *testing.T) {
+	orig := schedGetaffinity
+	defer func() { schedGetaffinity = orig }()
+	schedGetaffinity = func(int, *unix.CPUSet) error { return unix.EINVAL }
+
+	cpus, err := AllowedCPUs()
+	if err != nil || cpus != runtime.NumCPU() {
+		t.Errorf("AllowedCPUs() = %d, %v, want %d, nil", cpus, err, runtime.NumCPU())
+	}
+
+	r := MinCPUsCondition(1).evaluate()
+	if !r.Passed || r.Error != nil {
+		t.Errorf("MinCPUsCondition(1) = %+v", r)
+	}
+	if !strings.Contains(r.Detail, "affinity mask unavailable") || !strings.Contains(r.Detail, unix.EINVAL.Error()) {
+		t.Errorf("MinCPUsCondition(1) detail = %q, want the affinity error", r.Detail)
+	}
+}
@@ -0,0 +1,9 @@
+//go:build !linux
+
+package release
+
+import "runtime"
+
+func allowedCPUs() (int, error) {
+	return runtime.NumCPU(), nil
+}
@@ -0,0 +1,23 @@
+package release
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestAllowedCPUs(t *testing.T) {
+	cpus, err := AllowedCPUs()
+	if err != nil {
+		t.Fatalf("AllowedCPUs() error = %v", err)
+	}
+	if cpus < 1 || cpus > runtime.NumCPU() {
+		t.Errorf("AllowedCPUs() = %d, want between 1 and NumCPU (%d)", cpus, runtime.NumCPU())
+	}
+
+	if r := MinCPUsCondition(1).evaluate(); !r.Passed {
+		t.Errorf("MinCPUsCondition(1) = %+v", r)
+	}
+	if r := MinCPUsCondition(cpus + 1).evaluate(); r.Passed {
+		t.Errorf("MinCPUsCondition(%d) should fail: %+v", cpus+1, r)
+	}
+}
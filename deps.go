@@ -0,0 +1,137 @@
+package release
+
+import (
+	"fmt"
+	"runtime/debug"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Module describes a module dependency compiled into the binary
+type Module struct {
+	Path    string
+	Version string
+	Sum     string
+	// Replace is the module that replaced this one, if any
+	Replace *Module
+}
+
+// newModule converts a runtime/debug module, including its replacement
+func newModule(m *debug.Module) Module {
+	mod := Module{Path: m.Path, Version: m.Version, Sum: m.Sum}
+	if m.Replace != nil {
+		r := newModule(m.Replace)
+		mod.Replace = &r
+	}
+	return mod
+}
+
+// dependencies converts the build's module dependencies, sorted by path
+// and version so that serialized build info is reproducible and diffable
+func dependencies(deps []*debug.Module) []Module {
+	if len(deps) == 0 {
+		return nil
+	}
+	mods := make([]Module, 0, len(deps))
+	for _, dep := range deps {
+		if dep != nil {
+			mods = append(mods, newModule(dep))
+		}
+	}
+	sort.SliceStable(mods, func(i, j int) bool {
+		if mods[i].Path != mods[j].Path {
+			return mods[i].Path < mods[j].Path
+		}
+		return mods[i].Version < mods[j].Version
+	})
+	return mods
+}
+
+// HasReplaceDirectives reports whether any dependency of the binary was
+// replaced by a local directory, e.g. "replace example.com/lib => ../lib",
+// meaning the build used a possibly uncommitted local fork. Replacements
+// by another module version are not counted
+func HasReplaceDirectives() bool {
+	return len(localReplaces(GetBuildInfo().Dependencies)) > 0
+}
+
+// NoLocalReplaceCondition returns a condition that fails when any
+// dependency was replaced by a local directory, listing the offending
+// modules
+func NoLocalReplaceCondition() Condition {
+	return newDetailedCondition("no-local-replace", "No dependency is replaced by a local directory", func() (bool, string, error) {
+		local := localReplaces(GetBuildInfo().Dependencies)
+		if len(local) == 0 {
+			return true, "", nil
+		}
+		replaced := make([]string, len(local))
+		for i, mod := range local {
+			replaced[i] = fmt.Sprintf("%s => %s", mod.Path, mod.Replace.Path)
+		}
+		return false, "local replacements: " + strings.Join(replaced, ", "), nil
+	})
+}
+
+// localReplaces returns the modules replaced by a local directory. The go
+// command records such replacements with the version "(devel)", unlike
+// replacements by another module version
+func localReplaces(mods []Module) []Module {
+	var local []Module
+	for _, mod := range mods {
+		if mod.Replace != nil && (mod.Replace.Version == "(devel)" || mod.Replace.Version == "") {
+			local = append(local, mod)
+		}
+	}
+	return local
+}
+
+// DuplicateModules returns the module paths compiled into the binary at
+// more than one version, with their sorted versions. A correct build
+// never has any; duplicates point at a broken vendor directory or build
+// setup
+func DuplicateModules() map[string][]string {
+	return duplicateModules(GetBuildInfo().Dependencies)
+}
+
+// NoDuplicateModulesCondition returns a condition that fails when a module
+// is compiled into the binary at more than one version, listing the
+// conflicts
+func NoDuplicateModulesCondition() Condition {
+	return newDetailedCondition("no-duplicate-modules", "Every module is built at a single version", func() (bool, string, error) {
+		dups := DuplicateModules()
+		if len(dups) == 0 {
+			return true, "", nil
+		}
+		paths := make([]string, 0, len(dups))
+		for path := range dups {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+		conflicts := make([]string, len(paths))
+		for i, path := range paths {
+			conflicts[i] = fmt.Sprintf("%s (%s)", path, strings.Join(dups[path], ", "))
+		}
+		return false, "conflicting versions: " + strings.Join(conflicts, "; "), nil
+	})
+}
+
+// duplicateModules groups the distinct versions of each module path and
+// keeps the paths with more than one
+func duplicateModules(mods []Module) map[string][]string {
+	versions := make(map[string][]string)
+	for _, mod := range mods {
+		if !slices.Contains(versions[mod.Path], mod.Version) {
+			versions[mod.Path] = append(versions[mod.Path], mod.Version)
+		}
+	}
+
+	dups := make(map[string][]string)
+	for path, vs := range versions {
+		if len(vs) > 1 {
+			sort.Strings(vs)
+			dups[path] = vs
+		}
+	}
+	return dups
+}
@@ -0,0 +1,95 @@
+package release
+
+import (
+	"reflect"
+	"runtime/debug"
+	"sort"
+	"testing"
+)
+
+func TestDependenciesSorted(t *testing.T) {
+	deps := []*debug.Module{
+		{Path: "gopkg.in/yaml.v3", Version: "v3.0.1"},
+		{Path: "golang.org/x/sys", Version: "v0.30.0"},
+		{Path: "example.com/forked", Version: "v1.0.0", Replace: &debug.Module{Path: "../forked", Version: "(devel)"}},
+		{Path: "golang.org/x/mod", Version: "v0.14.0", Sum: "h1:abc="},
+	}
+
+	got := dependencies(deps)
+	want := []string{"example.com/forked", "golang.org/x/mod", "golang.org/x/sys", "gopkg.in/yaml.v3"}
+	if len(got) != len(want) {
+		t.Fatalf("dependencies() returned %d modules, want %d", len(got), len(want))
+	}
+	for i, path := range want {
+		if got[i].Path != path {
+			t.Errorf("dependencies()[%d].Path = %s, want %s", i, got[i].Path, path)
+		}
+	}
+	if got[0].Replace == nil || got[0].Replace.Path != "../forked" {
+		t.Errorf("Replace not preserved: %+v", got[0])
+	}
+	if got[1].Sum != "h1:abc=" {
+		t.Errorf("Sum not preserved: %+v", got[1])
+	}
+
+	if dependencies(nil) != nil {
+		t.Error("dependencies(nil) should be nil")
+	}
+}
+
+func TestGetBuildInfoDependenciesSorted(t *testing.T) {
+	deps := GetBuildInfo().Dependencies
+	if !sort.SliceIsSorted(deps, func(i, j int) bool { return deps[i].Path < deps[j].Path }) {
+		t.Errorf("Dependencies are not sorted by path: %+v", deps)
+	}
+}
+
+func TestLocalReplaces(t *testing.T) {
+	mods := []Module{
+		{Path: "example.com/forked", Version: "v1.0.0", Replace: &Module{Path: "../forked", Version: "(devel)"}},
+		{Path: "example.com/pinned", Version: "v1.0.0", Replace: &Module{Path: "example.com/pinned", Version: "v1.0.1", Sum: "h1:abc="}},
+		{Path: "example.com/plain", Version: "v2.0.0"},
+		{Path: "example.com/vendored", Version: "v0.1.0", Replace: &Module{Path: "/src/vendored"}},
+	}
+	got := localReplaces(mods)
+	if len(got) != 2 || got[0].Path != "example.com/forked" || got[1].Path != "example.com/vendored" {
+		t.Errorf("localReplaces() = %+v, want the forked and vendored modules", got)
+	}
+	if localReplaces(mods[1:3]) != nil {
+		t.Error("localReplaces() should ignore module replacements")
+	}
+}
+
+func TestNoLocalReplaceCondition(t *testing.T) {
+	r := NoLocalReplaceCondition().evaluate()
+	if r.Error != nil {
+		t.Fatalf("Unexpected error: %v", r.Error)
+	}
+	if r.Passed != !HasReplaceDirectives() {
+		t.Errorf("Condition result %+v disagrees with HasReplaceDirectives()", r)
+	}
+}
+
+func TestDuplicateModules(t *testing.T) {
+	mods := []Module{
+		{Path: "example.com/a", Version: "v1.2.0"},
+		{Path: "example.com/a", Version: "v1.1.0"},
+		{Path: "example.com/b", Version: "v0.3.0"},
+		{Path: "example.com/b", Version: "v0.3.0"},
+		{Path: "example.com/c", Version: "v2.0.0"},
+	}
+	want := map[string][]string{"example.com/a": {"v1.1.0", "v1.2.0"}}
+	if got := duplicateModules(mods); !reflect.DeepEqual(got, want) {
+		t.Errorf("duplicateModules() = %v, want %v", got, want)
+	}
+	if got := duplicateModules(nil); len(got) != 0 {
+		t.Errorf("duplicateModules(nil) = %v, want empty", got)
+	}
+}
+
+func TestNoDuplicateModulesCondition(t *testing.T) {
+	r := NoDuplicateModulesCondition().evaluate()
+	if r.Error != nil || r.Passed != (len(DuplicateModules()) == 0) {
+		t.Errorf("Condition result %+v disagrees with DuplicateModules()", r)
+	}
+}
@@ -0,0 +1,71 @@
+package release
+
+import "maps"
+
+// ConditionSetDiff lists the differences between the definitions of two
+// condition sets (see DiffConditionSets)
+type ConditionSetDiff struct {
+	// Added holds the conditions only in the second set, in its order
+	Added []string
+	// Removed holds the conditions only in the first set, in its order
+	Removed []string
+	// Changed holds the conditions in both sets whose definitions differ,
+	// in the order of the first set
+	Changed []string
+}
+
+// Empty reports whether the sets have the same definitions
+func (d ConditionSetDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// DiffConditionSets compares the definitions of a and b without running
+// any checks, e.g. to report drift between a service's gate and a
+// standard template. Conditions are matched by name and changed when
+// their description, labels, advisory flag or exit code differ, or, for
+// groups (see AddGroup), when the definitions of their conditions differ;
+// check functions cannot be compared. When a name is repeated, only the first
+// condition with that name is compared (see Get)
+func DiffConditionSets(a, b *ConditionSet) ConditionSetDiff {
+	var diff ConditionSetDiff
+	seen := make(map[string]bool)
+	for _, cond := range a.conditions {
+		if seen[cond.Name] {
+			continue
+		}
+		seen[cond.Name] = true
+		other, ok := b.Get(cond.Name)
+		switch {
+		case !ok:
+			diff.Removed = append(diff.Removed, cond.Name)
+		case !sameDefinition(cond, other):
+			diff.Changed = append(diff.Changed, cond.Name)
+		}
+	}
+	for _, cond := range b.conditions {
+		if !seen[cond.Name] {
+			seen[cond.Name] = true
+			diff.Added = append(diff.Added, cond.Name)
+		}
+	}
+	return diff
+}
+
+// sameDefinition compares the declarative fields of two conditions,
+// descending into groups
+func sameDefinition(a, b Condition) bool {
+	return a.Description == b.Description &&
+		a.ErrorIsPass == b.ErrorIsPass &&
+		a.ExitCode == b.ExitCode &&
+		maps.Equal(a.Labels, b.Labels) &&
+		sameGroup(a.group, b.group)
+}
+
+// sameGroup reports whether two groups, either of which may be nil for a
+// plain condition, have the same definitions
+func sameGroup(a, b *ConditionSet) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return DiffConditionSets(a, b).Empty()
+}
@@ -0,0 +1,82 @@
+package release
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDiffConditionSets(t *testing.T) {
+	pass := func() (bool, error) { return true, nil }
+
+	a := NewConditionSet()
+	a.Add("go-version", "Go 1.21+", pass)
+	a.Add("os", "Linux", pass)
+	a.AddAll(Condition{Name: "db", Description: "DB reachable", Check: pass}.WithLabel("stage", "deploy"))
+	a.AddAdvisory("dns", "DNS resolves", pass)
+	a.Add("cpus", "Enough CPUs", pass)
+	a.Add("cpus", "Duplicate ignored", pass)
+
+	b := NewConditionSet()
+	b.Add("cache", "Cache reachable", pass)
+	b.Add("go-version", "Go 1.21+", func() (bool, error) { return false, nil })
+	b.Add("os", "Linux or macOS", pass)
+	b.AddAll(Condition{Name: "db", Description: "DB reachable", Check: pass}.WithLabel("stage", "runtime"))
+	b.Add("dns", "DNS resolves", pass)
+	b.AddAll(Condition{Name: "cpus", Description: "Enough CPUs", Check: pass, ExitCode: 3})
+	b.Add("cache", "Duplicate ignored", pass)
+
+	diff := DiffConditionSets(a, b)
+	if want := []string{"cache"}; !slices.Equal(diff.Added, want) {
+		t.Errorf("Added = %v, want %v", diff.Added, want)
+	}
+	if len(diff.Removed) != 0 {
+		t.Errorf("Removed = %v, want none", diff.Removed)
+	}
+	if want := []string{"os", "db", "dns", "cpus"}; !slices.Equal(diff.Changed, want) {
+		t.Errorf("Changed = %v, want %v", diff.Changed, want)
+	}
+	if diff.Empty() {
+		t.Error("Empty() = true for differing sets")
+	}
+
+	reverse := DiffConditionSets(b, a)
+	if want := []string{"cache"}; !slices.Equal(reverse.Removed, want) || len(reverse.Added) != 0 {
+		t.Errorf("DiffConditionSets(b, a) = %+v", reverse)
+	}
+
+	if diff := DiffConditionSets(a, a); !diff.Empty() {
+		t.Errorf("DiffConditionSets(a, a) = %+v, want empty", diff)
+	}
+
+	// Labels are compared by content, so nil and empty maps are the same
+	c := NewConditionSet()
+	c.AddAll(Condition{Name: "x", Labels: map[string]string{}})
+	d := NewConditionSet()
+	d.AddAll(Condition{Name: "x"})
+	if diff := DiffConditionSets(c, d); !diff.Empty() {
+		t.Errorf("DiffConditionSets() with empty labels = %+v, want empty", diff)
+	}
+}
+
+func TestDiffConditionSetsGroups(t *testing.T) {
+	pass := func() (bool, error) { return true, nil }
+	group := func(description string) *ConditionSet {
+		g := NewConditionSet()
+		g.Add("db", description, pass)
+		return g
+	}
+
+	a := NewConditionSet()
+	a.AddGroup("storage", "Storage ready", group("DB reachable"))
+	a.AddGroup("cache", "Cache ready", group("DB reachable"))
+	a.Add("plain", "Not a group", pass)
+
+	b := NewConditionSet()
+	b.AddGroup("storage", "Storage ready", group("DB reachable and writable"))
+	b.AddGroup("cache", "Cache ready", group("DB reachable"))
+	b.AddGroup("plain", "Not a group", group("DB reachable"))
+
+	if want := []string{"storage", "plain"}; !slices.Equal(DiffConditionSets(a, b).Changed, want) {
+		t.Errorf("Changed = %v, want %v", DiffConditionSets(a, b).Changed, want)
+	}
+}
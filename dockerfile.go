@@ -0,0 +1,141 @@
+package release
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// dockerGoTag matches the Go version at the start of a golang image tag,
+// e.g. "1.22" in "1.22.3-alpine"
+var dockerGoTag = regexp.MustCompile(`^\d+\.\d+(\.\d+)?((rc|beta)\d+)?`)
+
+// ParseDockerfileGoVersion returns the Go version of the golang base
+// image in a Dockerfile, e.g. "1.22.3" for FROM golang:1.22.3-alpine. With
+// several FROM lines it uses the golang image of a builder stage (one
+// whose name contains "build", e.g. AS builder), falling back to the first
+// golang image. ARG defaults declared before the first FROM are expanded
+func ParseDockerfileGoVersion(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	version, err := parseDockerfileGoVersion(data)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", path, err)
+	}
+	return version, nil
+}
+
+// MatchesDockerfileGoVersion reports whether the running Go version is on
+// the same major.minor line as the Dockerfile's golang base image (see
+// ParseDockerfileGoVersion), catching builder/runtime skew
+func MatchesDockerfileGoVersion(path string) (bool, error) {
+	version, err := ParseDockerfileGoVersion(path)
+	if err != nil {
+		return false, err
+	}
+	return SameMinorLine(version)
+}
+
+// dockerStage is a FROM instruction using a golang image
+type dockerStage struct {
+	tag  string
+	name string
+}
+
+// parseDockerfileGoVersion extracts the Go version from Dockerfile contents
+func parseDockerfileGoVersion(data []byte) (string, error) {
+	args := make(map[string]string)
+	var stages []dockerStage
+	for _, line := range dockerfileInstructions(data) {
+		fields := strings.Fields(line)
+		switch strings.ToUpper(fields[0]) {
+		case "ARG":
+			// Only global ARGs, declared before the first FROM, apply to FROM lines
+			if len(stages) == 0 && len(fields) > 1 {
+				name, value, _ := strings.Cut(fields[1], "=")
+				args[name] = strings.Trim(value, `"'`)
+			}
+		case "FROM":
+			if stage, ok := parseFrom(fields[1:], args); ok {
+				stages = append(stages, stage)
+			}
+		}
+	}
+
+	if len(stages) == 0 {
+		return "", errors.New("no golang base image found")
+	}
+	chosen := stages[0]
+	for _, stage := range stages {
+		if strings.Contains(strings.ToLower(stage.name), "build") {
+			chosen = stage
+			break
+		}
+	}
+
+	version := dockerGoTag.FindString(chosen.tag)
+	if version == "" {
+		return "", fmt.Errorf("golang image tag %q does not name a Go version", chosen.tag)
+	}
+	return version, nil
+}
+
+// parseFrom parses the arguments of a FROM instruction, reporting whether
+// it uses a golang image
+func parseFrom(fields []string, args map[string]string) (dockerStage, bool) {
+	var image, name string
+	for i := 0; i < len(fields); i++ {
+		switch {
+		case strings.HasPrefix(fields[i], "--"):
+			// Flags such as --platform=$BUILDPLATFORM
+		case image == "":
+			image = os.Expand(fields[i], func(key string) string { return args[key] })
+		case strings.EqualFold(fields[i], "AS") && i+1 < len(fields):
+			name = fields[i+1]
+			i++
+		}
+	}
+
+	image, _, _ = strings.Cut(image, "@")
+	repo, tag := image, ""
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		repo, tag = image[:i], image[i+1:]
+	}
+	if repo != "golang" && !strings.HasSuffix(repo, "/golang") {
+		return dockerStage{}, false
+	}
+	return dockerStage{tag: tag, name: name}, true
+}
+
+// dockerfileInstructions returns the instructions of a Dockerfile with
+// comments and blank lines removed and continuation lines joined
+func dockerfileInstructions(data []byte) []string {
+	var instructions []string
+	var current strings.Builder
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.HasSuffix(line, `\`) {
+			current.WriteString(strings.TrimSuffix(line, `\`) + " ")
+			continue
+		}
+		current.WriteString(line)
+		if instruction := strings.TrimSpace(current.String()); instruction != "" {
+			instructions = append(instructions, instruction)
+		}
+		current.Reset()
+	}
+	if instruction := strings.TrimSpace(current.String()); instruction != "" {
+		instructions = append(instructions, instruction)
+	}
+	return instructions
+}
@@ -0,0 +1,76 @@
+package release
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestParseDockerfileGoVersion(t *testing.T) {
+	tests := []struct {
+		name       string
+		dockerfile string
+		want       string
+		wantErr    bool
+	}{
+		{"simple", "FROM golang:1.22\n", "1.22", false},
+		{"variant", "FROM golang:1.22.3-alpine3.19 AS build\n", "1.22.3", false},
+		{"registry", "FROM docker.io/library/golang:1.21.5@sha256:abc AS builder\n", "1.21.5", false},
+		{"platform flag", "FROM --platform=$BUILDPLATFORM golang:1.23rc1 AS builder\n", "1.23rc1", false},
+		{
+			"builder stage preferred",
+			"FROM golang:1.20 AS tools\nFROM golang:1.22-bookworm AS builder\nFROM gcr.io/distroless/static\n",
+			"1.22", false,
+		},
+		{
+			"first golang image",
+			"# runtime image below\nFROM alpine:3.19 AS base\nFROM golang:1.21 AS compile\nFROM golang:1.22\n",
+			"1.21", false,
+		},
+		{"global arg", "ARG GO_VERSION=1.22.1\nFROM golang:${GO_VERSION}-alpine AS build\n", "1.22.1", false},
+		{"continuation", "FROM \\\n  golang:1.22 \\\n  AS builder\nRUN go build ./...\n", "1.22", false},
+		{"lowercase", "from golang:1.22 as builder\n", "1.22", false},
+		{"no golang image", "FROM alpine:3.19\n", "", true},
+		{"not golang", "FROM example.com/golang-tools:1.0\n", "", true},
+		{"no version", "FROM golang:alpine\n", "", true},
+		{"untagged", "FROM golang\n", "", true},
+	}
+	for _, tt := range tests {
+		got, err := parseDockerfileGoVersion([]byte(tt.dockerfile))
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: parseDockerfileGoVersion() error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%s: parseDockerfileGoVersion() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestMatchesDockerfileGoVersion(t *testing.T) {
+	major, minor, err := GetGoMajorMinor()
+	if err != nil {
+		t.Skipf("cannot parse runtime version %s", runtime.Version())
+	}
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	current := fmt.Sprintf("FROM golang:%d.%d-alpine AS builder\n", major, minor)
+	if ok, err := MatchesDockerfileGoVersion(write("Dockerfile", current)); !ok || err != nil {
+		t.Errorf("MatchesDockerfileGoVersion() = %v, %v, want true", ok, err)
+	}
+	if ok, err := MatchesDockerfileGoVersion(write("Dockerfile.old", "FROM golang:1.10\n")); ok || err != nil {
+		t.Errorf("MatchesDockerfileGoVersion(1.10) = %v, %v, want false", ok, err)
+	}
+	if _, err := MatchesDockerfileGoVersion(filepath.Join(dir, "missing")); err == nil {
+		t.Error("MatchesDockerfileGoVersion() on a missing file should fail")
+	}
+}
@@ -0,0 +1,54 @@
+package release
+
+import (
+	"os"
+	"strings"
+)
+
+// EnvironmentResolver determines the deployment environment from layered
+// sources. Resolve consults them in a fixed order and returns the first
+// that is set:
+//
+//  1. Override, an explicit value, e.g. injected by tests
+//  2. The env var named by EnvVar (EnvironmentEnvVar when empty)
+//  3. ConfigFile, a file holding just the environment name
+//  4. Default
+//
+// Values from the env var and config file are trimmed and lower-cased. A
+// missing or unreadable config file is treated as unset
+type EnvironmentResolver struct {
+	Override   Environment
+	EnvVar     string
+	ConfigFile string
+	Default    Environment
+}
+
+// Resolve returns the environment from the highest-precedence source that
+// is set, or "" when none is
+func (r EnvironmentResolver) Resolve() Environment {
+	if r.Override != "" {
+		return r.Override
+	}
+
+	name := r.EnvVar
+	if name == "" {
+		name = EnvironmentEnvVar
+	}
+	if env := normalizeEnvironment(os.Getenv(name)); env != "" {
+		return env
+	}
+
+	if r.ConfigFile != "" {
+		if data, err := os.ReadFile(r.ConfigFile); err == nil {
+			if env := normalizeEnvironment(string(data)); env != "" {
+				return env
+			}
+		}
+	}
+	return r.Default
+}
+
+// normalizeEnvironment trims and lower-cases an environment name
+func normalizeEnvironment(s string) Environment {
+	return Environment(strings.ToLower(strings.TrimSpace(s)))
+}
@@ -0,0 +1,43 @@
+package release
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestEnvironmentResolver(t *testing.T) {
+	dir := t.TempDir()
+	configFile := filepath.Join(dir, "environment")
+	if err := os.WriteFile(configFile, []byte(" Staging\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	const envVar = "RELEASE_TEST_ENV"
+
+	tests := []struct {
+		name     string
+		envValue string
+		resolver EnvironmentResolver
+		want     Environment
+	}{
+		{"override wins", "production", EnvironmentResolver{Override: EnvTest, EnvVar: envVar, ConfigFile: configFile, Default: EnvDevelopment}, EnvTest},
+		{"env var over config", "Production", EnvironmentResolver{EnvVar: envVar, ConfigFile: configFile, Default: EnvDevelopment}, EnvProduction},
+		{"config over default", "", EnvironmentResolver{EnvVar: envVar, ConfigFile: configFile, Default: EnvDevelopment}, EnvStaging},
+		{"missing config file", "", EnvironmentResolver{EnvVar: envVar, ConfigFile: filepath.Join(dir, "missing"), Default: EnvDevelopment}, EnvDevelopment},
+		{"blank env var", "  ", EnvironmentResolver{EnvVar: envVar, Default: EnvDevelopment}, EnvDevelopment},
+		{"nothing set", "", EnvironmentResolver{EnvVar: envVar}, ""},
+	}
+	for _, tt := range tests {
+		t.Setenv(envVar, tt.envValue)
+		if got := tt.resolver.Resolve(); got != tt.want {
+			t.Errorf("%s: Resolve() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestEnvironmentResolverDefaultEnvVar(t *testing.T) {
+	t.Setenv(EnvironmentEnvVar, "staging")
+	if got := (EnvironmentResolver{Default: EnvProduction}).Resolve(); got != EnvStaging {
+		t.Errorf("Resolve() = %q, want %q from %s", got, EnvStaging, EnvironmentEnvVar)
+	}
+}
@@ -0,0 +1,161 @@
+package release
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// releaseDate returns midnight UTC on the given day
+func releaseDate(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// defaultGoReleaseDates holds the release dates of Go minor versions,
+// keyed by "major.minor". Add new releases as they ship
+var defaultGoReleaseDates = map[string]time.Time{
+	"1.13": releaseDate(2019, time.September, 3),
+	"1.14": releaseDate(2020, time.February, 25),
+	"1.15": releaseDate(2020, time.August, 11),
+	"1.16": releaseDate(2021, time.February, 16),
+	"1.17": releaseDate(2021, time.August, 16),
+	"1.18": releaseDate(2022, time.March, 15),
+	"1.19": releaseDate(2022, time.August, 2),
+	"1.20": releaseDate(2023, time.February, 1),
+	"1.21": releaseDate(2023, time.August, 8),
+	"1.22": releaseDate(2024, time.February, 6),
+	"1.23": releaseDate(2024, time.August, 13),
+	"1.24": releaseDate(2025, time.February, 11),
+	"1.25": releaseDate(2025, time.August, 12),
+	"1.26": releaseDate(2026, time.February, 10),
+	"1.27": releaseDate(2026, time.August, 11),
+}
+
+// goReleases is the release date table in use, keyed by major and minor
+var (
+	goReleasesMu sync.RWMutex
+	goReleases   = mustParseReleaseDates(defaultGoReleaseDates)
+)
+
+// ErrStaleReleaseTable is returned by the EOL checks for a Go version at
+// or past the newest release in the release date table, whose end of life
+// the table cannot tell. Update the library or call SetGoReleaseDates
+var ErrStaleReleaseTable = errors.New("release table is stale")
+
+// releaseKey identifies a Go minor version
+type releaseKey struct{ major, minor int }
+
+// before reports whether k is an earlier minor version than o
+func (k releaseKey) before(o releaseKey) bool {
+	return k.major < o.major || k.major == o.major && k.minor < o.minor
+}
+
+// SetGoReleaseDates replaces the table of Go minor version release dates
+// used for EOL checks, keyed by "major.minor" (e.g. "1.22"), for users
+// who manage their own support policy or need releases newer than the
+// embedded table. A nil table restores the embedded one
+func SetGoReleaseDates(dates map[string]time.Time) error {
+	if dates == nil {
+		dates = defaultGoReleaseDates
+	}
+	parsed, err := parseReleaseDates(dates)
+	if err != nil {
+		return err
+	}
+
+	goReleasesMu.Lock()
+	defer goReleasesMu.Unlock()
+	goReleases = parsed
+	return nil
+}
+
+// IsGoVersionEOL reports whether the running Go version is past its end
+// of life. Each minor version is supported until two newer minor versions
+// have been released, e.g. Go 1.21 reached EOL with the release of 1.23.
+// Versions older than the release date table are EOL. For versions at or
+// past the newest release in the table it returns ErrStaleReleaseTable,
+// and a table missing a release in between is also reported as an error
+func IsGoVersionEOL() (bool, error) {
+	eol, _, err := goVersionEOL(runtime.Version(), now())
+	return eol, err
+}
+
+// SupportedGoVersionCondition returns a condition that fails when the
+// running Go version is past its end of life (see IsGoVersionEOL)
+func SupportedGoVersionCondition() Condition {
+	return newDetailedCondition("supported-go-version", "Go version is not end-of-life", func() (bool, string, error) {
+		eol, detail, err := goVersionEOL(runtime.Version(), now())
+		if err != nil {
+			return false, "", err
+		}
+		return !eol, detail, nil
+	})
+}
+
+// goVersionEOL reports whether version is past its end of life at t,
+// explaining the outcome
+func goVersionEOL(version string, t time.Time) (bool, string, error) {
+	major, minor, err := parseGoMajorMinor(version)
+	if err != nil {
+		return false, "", err
+	}
+
+	version = fmt.Sprintf("go%d.%d", major, minor)
+	key, next := releaseKey{major, minor}, releaseKey{major, minor + 2}
+
+	goReleasesMu.RLock()
+	successor, ok := goReleases[next]
+	oldest, newest := releaseRange(goReleases)
+	goReleasesMu.RUnlock()
+
+	switch {
+	case ok && !successor.After(t):
+		return true, fmt.Sprintf("%s reached end of life on %s with the release of go%d.%d", version, successor.Format("2006-01-02"), next.major, next.minor), nil
+	case !key.before(newest):
+		return false, "", fmt.Errorf("%w: %s is at or past its newest release go%d.%d", ErrStaleReleaseTable, version, newest.major, newest.minor)
+	case ok || newest.before(next):
+		return false, fmt.Sprintf("%s is supported until go%d.%d is released", version, next.major, next.minor), nil
+	case key.before(oldest):
+		return true, fmt.Sprintf("%s is older than every release in the release date table, which starts at go%d.%d", version, oldest.major, oldest.minor), nil
+	default:
+		return false, "", fmt.Errorf("release date table has no entry for go%d.%d", next.major, next.minor)
+	}
+}
+
+// releaseRange returns the oldest and newest versions in releases
+func releaseRange(releases map[releaseKey]time.Time) (oldest, newest releaseKey) {
+	first := true
+	for key := range releases {
+		if first || key.before(oldest) {
+			oldest = key
+		}
+		if first || newest.before(key) {
+			newest = key
+		}
+		first = false
+	}
+	return oldest, newest
+}
+
+// parseReleaseDates converts a "major.minor" keyed table
+func parseReleaseDates(dates map[string]time.Time) (map[releaseKey]time.Time, error) {
+	parsed := make(map[releaseKey]time.Time, len(dates))
+	for version, released := range dates {
+		major, minor, err := parseGoMajorMinor(version)
+		if err != nil {
+			return nil, fmt.Errorf("release date table: %w", err)
+		}
+		parsed[releaseKey{major, minor}] = released
+	}
+	return parsed, nil
+}
+
+func mustParseReleaseDates(dates map[string]time.Time) map[releaseKey]time.Time {
+	parsed, err := parseReleaseDates(dates)
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}
@@ -0,0 +1,107 @@
+package release
+
+import (
+	"errors"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGoVersionEOL(t *testing.T) {
+	tests := []struct {
+		version string
+		at      time.Time
+		want    bool
+	}{
+		{"go1.21.5", releaseDate(2024, time.August, 12), false},
+		{"go1.21.5", releaseDate(2024, time.August, 13), true},
+		{"go1.22rc1", releaseDate(2024, time.January, 1), false},
+		{"go1.13", releaseDate(2024, time.January, 1), true},
+		{"go1.5", releaseDate(2016, time.January, 1), true},
+		{"go1.25.1", releaseDate(2026, time.August, 11), true},
+		{"go1.26.1", releaseDate(2026, time.September, 1), false},
+	}
+	for _, tt := range tests {
+		got, detail, err := goVersionEOL(tt.version, tt.at)
+		if err != nil {
+			t.Errorf("goVersionEOL(%q) error = %v", tt.version, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("goVersionEOL(%q, %s) = %v (%s), want %v", tt.version, tt.at.Format("2006-01-02"), got, detail, tt.want)
+		}
+	}
+
+	if _, _, err := goVersionEOL("devel", time.Now()); err == nil {
+		t.Error("goVersionEOL(devel) should fail")
+	}
+
+	// The table cannot tell when the newest release or later ones end
+	for _, version := range []string{"go1.27.1", "go1.99.0"} {
+		if _, _, err := goVersionEOL(version, releaseDate(2026, time.September, 1)); !errors.Is(err, ErrStaleReleaseTable) {
+			t.Errorf("goVersionEOL(%q) error = %v, want ErrStaleReleaseTable", version, err)
+		}
+	}
+}
+
+func TestSetGoReleaseDates(t *testing.T) {
+	defer SetGoReleaseDates(nil)
+
+	if err := SetGoReleaseDates(map[string]time.Time{"1.99": releaseDate(2030, time.January, 1), "1.101": releaseDate(2031, time.January, 1)}); err != nil {
+		t.Fatalf("SetGoReleaseDates() error = %v", err)
+	}
+	eol, detail, _ := goVersionEOL("go1.99.1", releaseDate(2031, time.June, 1))
+	if !eol || !strings.Contains(detail, "2031-01-01") {
+		t.Errorf("goVersionEOL() with a custom table = %v, %q", eol, detail)
+	}
+	// The embedded table is no longer used, so go1.25 predates the table
+	if eol, _, _ := goVersionEOL("go1.25", releaseDate(2024, time.January, 1)); !eol {
+		t.Error("Custom table should replace the embedded one")
+	}
+	// The successor of go1.100 is newer than the table
+	if eol, _, err := goVersionEOL("go1.100", releaseDate(2031, time.June, 1)); eol || err != nil {
+		t.Errorf("goVersionEOL(go1.100) = %v, %v, want supported", eol, err)
+	}
+	if err := SetGoReleaseDates(map[string]time.Time{"1.20": releaseDate(2023, time.February, 1), "1.23": releaseDate(2024, time.August, 13)}); err != nil {
+		t.Fatalf("SetGoReleaseDates() error = %v", err)
+	}
+	if _, _, err := goVersionEOL("go1.20", releaseDate(2024, time.January, 1)); err == nil {
+		t.Error("goVersionEOL() should fail for a gap in the release date table")
+	}
+
+	if err := SetGoReleaseDates(map[string]time.Time{"latest": time.Now()}); err == nil {
+		t.Error("SetGoReleaseDates() should reject invalid versions")
+	}
+
+	SetGoReleaseDates(nil)
+	if eol, _, _ := goVersionEOL("go1.13", releaseDate(2024, time.January, 1)); !eol {
+		t.Error("SetGoReleaseDates(nil) should restore the embedded table")
+	}
+}
+
+func TestSupportedGoVersionCondition(t *testing.T) {
+	r := SupportedGoVersionCondition().evaluate()
+	eol, err := IsGoVersionEOL()
+	switch {
+	case err != nil:
+		if r.Passed || r.Error == nil || r.Error.Error() != err.Error() {
+			t.Errorf("SupportedGoVersionCondition() = %+v, want a failure with %v", r, err)
+		}
+	case r.Error != nil || r.Detail == "" || r.Passed == eol:
+		t.Errorf("SupportedGoVersionCondition() = %+v, IsGoVersionEOL() = %v", r, eol)
+	}
+}
+
+func TestSupportedGoVersionConditionStaleTable(t *testing.T) {
+	defer SetGoReleaseDates(nil)
+
+	// A table ending at go1.1 is older than any running version
+	if err := SetGoReleaseDates(map[string]time.Time{"1.0": releaseDate(2012, time.March, 28), "1.1": releaseDate(2013, time.May, 13)}); err != nil {
+		t.Fatalf("SetGoReleaseDates() error = %v", err)
+	}
+	r := SupportedGoVersionCondition().evaluate()
+	if r.Passed || !errors.Is(r.Error, ErrStaleReleaseTable) {
+		t.Errorf("SupportedGoVersionCondition() on %s = %+v, want ErrStaleReleaseTable", runtime.Version(), r)
+	}
+}
@@ -65,12 +65,12 @@ func ExampleCompareGoVersion() {
 		return
 	}
 
-	switch cmp {
-	case -1:
+	switch {
+	case cmp.IsLess():
 		fmt.Println("Current Go version is older than 1.20")
-	case 0:
+	case cmp.IsEqual():
 		fmt.Println("Current Go version is exactly 1.20")
-	case 1:
+	case cmp.IsGreater():
 		fmt.Println("Current Go version is newer than 1.20")
 	}
 }
@@ -4,6 +4,9 @@ go 1.21
 
 require github.com/parthban-db/test-go-release v0.0.0
 
-require golang.org/x/mod v0.14.0 // indirect
+require (
+	golang.org/x/sys v0.30.0 // indirect
+	golang.org/x/time v0.9.0 // indirect
+)
 
 replace github.com/parthban-db/test-go-release => ../..
@@ -112,23 +112,13 @@ func runReleaseChecks() {
 		return release.IsArch("amd64") || release.IsArch("arm64"), nil
 	})
 
-	cs.Add("multi-cpu", "At least 2 CPUs available", func() (bool, error) {
-		info := release.GetBuildInfo()
-		return info.NumCPU >= 2, nil
-	})
+	cs.AddAll(release.MinCPUsCondition(2))
 
 	// Test all conditions
 	results := cs.TestAll()
 
-	for _, result := range results {
-		status := "✓"
-		if !result.Passed {
-			status = "✗"
-		}
-		fmt.Printf("  %s %s: %s\n", status, result.Name, result.Description)
-		if result.Error != nil {
-			fmt.Printf("     Error: %v\n", result.Error)
-		}
+	if err := results.WriteTable(os.Stdout, release.ReportOptions{}); err != nil {
+		fmt.Printf("  Error writing report: %v\n", err)
 	}
 
 	fmt.Println()
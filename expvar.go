@@ -0,0 +1,44 @@
+package release
+
+import (
+	"expvar"
+	"sync"
+)
+
+var (
+	expvarsOnce sync.Once
+	expvars     *expvar.Map
+)
+
+// PublishExpvars registers the build info and the readiness of cs as
+// expvar variables under the "release" namespace, served by the
+// /debug/vars handler:
+//
+//	"release": {"arch": "amd64", "go_version": "go1.22.1", "module_version": "v1.4.0", "os": "linux", "ready": true, "vcs_revision": "..."}
+//
+// "ready" runs every condition of cs each time the variables are read, so
+// expensive conditions should be added with AddBackground, or with AddOnce
+// for facts that cannot change; Memoize only shares a probe within one
+// read. Calling PublishExpvars again rebinds "ready" to the new set rather
+// than panicking on the duplicate name
+func (cs *ConditionSet) PublishExpvars() {
+	expvarsOnce.Do(func() {
+		expvars = expvar.NewMap("release")
+	})
+
+	info := GetBuildInfo()
+	for key, value := range map[string]string{
+		"go_version":     info.GoVersion,
+		"os":             info.OS,
+		"arch":           info.Arch,
+		"vcs_revision":   info.VCSRevision,
+		"module_version": info.ModuleVersion,
+	} {
+		v := new(expvar.String)
+		v.Set(value)
+		expvars.Set(key, v)
+	}
+	expvars.Set("ready", expvar.Func(func() any {
+		return cs.TestAll().AllPassed()
+	}))
+}
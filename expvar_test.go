@@ -0,0 +1,47 @@
+package release
+
+import (
+	"encoding/json"
+	"expvar"
+	"runtime"
+	"testing"
+)
+
+func TestPublishExpvars(t *testing.T) {
+	ready := true
+	cs := NewConditionSet()
+	cs.Add("toggle", "Ready when toggled", func() (bool, error) { return ready, nil })
+	cs.PublishExpvars()
+
+	read := func() map[string]any {
+		t.Helper()
+		v := expvar.Get("release")
+		if v == nil {
+			t.Fatal("Expected the release namespace to be published")
+		}
+		var vars map[string]any
+		if err := json.Unmarshal([]byte(v.String()), &vars); err != nil {
+			t.Fatalf("Invalid expvar JSON: %v", err)
+		}
+		return vars
+	}
+
+	vars := read()
+	if vars["go_version"] != runtime.Version() || vars["os"] != runtime.GOOS || vars["arch"] != runtime.GOARCH {
+		t.Errorf("Unexpected build info: %v", vars)
+	}
+	if vars["ready"] != true {
+		t.Errorf("Expected ready, got %v", vars["ready"])
+	}
+
+	ready = false
+	if vars := read(); vars["ready"] != false {
+		t.Errorf("Expected readiness to be re-evaluated, got %v", vars["ready"])
+	}
+
+	// Publishing again must not panic and rebinds readiness
+	NewConditionSet().PublishExpvars()
+	if vars := read(); vars["ready"] != true {
+		t.Errorf("Expected the empty set to be ready, got %v", vars["ready"])
+	}
+}
@@ -0,0 +1,67 @@
+package release
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"runtime/debug"
+	"runtime/metrics"
+)
+
+// gcPercent returns the current GC percent, or -1 when GC is off. It is
+// read from runtime/metrics, so the setting is never changed, and falls
+// back to the runtime default of 100 if the metric is unavailable
+func gcPercent() int {
+	sample := []metrics.Sample{{Name: "/gc/gogc:percent"}}
+	metrics.Read(sample)
+	if sample[0].Value.Kind() != metrics.KindUint64 {
+		return 100
+	}
+	// GC off is reported as the uint64 representation of -1
+	return int(int64(sample[0].Value.Uint64()))
+}
+
+// hasMemoryLimit reports whether a soft memory limit (GOMEMLIMIT or
+// debug.SetMemoryLimit) is in effect. A negative input only reads the limit
+func hasMemoryLimit() bool {
+	return debug.SetMemoryLimit(-1) != math.MaxInt64
+}
+
+// GCEnabledCondition returns a condition that fails when the garbage
+// collector is disabled, via GOGC=off or debug.SetGCPercent(-1). Use
+// GCEnabledOrMemoryLimitCondition to allow GC off with a memory limit
+func GCEnabledCondition() Condition {
+	return gcEnabledCondition("gc-enabled", "Garbage collector is enabled", false)
+}
+
+// GCEnabledOrMemoryLimitCondition is like GCEnabledCondition but also
+// passes when GC is off and a memory limit is set, which is the documented
+// way to let the limit alone drive collection
+func GCEnabledOrMemoryLimitCondition() Condition {
+	return gcEnabledCondition("gc-enabled-or-memory-limit", "Garbage collector is enabled or a memory limit is set", true)
+}
+
+// gcEnabledCondition builds the GC conditions, allowing GC off with a
+// memory limit only when allowMemoryLimit is set
+func gcEnabledCondition(name, description string, allowMemoryLimit bool) Condition {
+	return newDetailedCondition(name, description, func() (bool, string, error) {
+		passed, detail := checkGCEnabled(os.Getenv("GOGC"), gcPercent(), allowMemoryLimit && hasMemoryLimit())
+		return passed, detail, nil
+	})
+}
+
+// checkGCEnabled evaluates the GC settings, explaining the outcome.
+// memoryLimit reports an accepted memory limit
+func checkGCEnabled(gogc string, percent int, memoryLimit bool) (bool, string) {
+	if gogc == "" {
+		gogc = "unset"
+	}
+	switch {
+	case percent >= 0:
+		return true, fmt.Sprintf("GC percent is %d (GOGC=%s)", percent, gogc)
+	case memoryLimit:
+		return true, fmt.Sprintf("GC is disabled (GOGC=%s) but a memory limit is set", gogc)
+	default:
+		return false, fmt.Sprintf("garbage collection is disabled (GOGC=%s)", gogc)
+	}
+}
@@ -0,0 +1,65 @@
+package release
+
+import (
+	"runtime/debug"
+	"strings"
+	"testing"
+)
+
+func TestGCPercent(t *testing.T) {
+	orig := debug.SetGCPercent(150)
+	defer debug.SetGCPercent(orig)
+
+	if got := gcPercent(); got != 150 {
+		t.Errorf("gcPercent() = %d, want 150", got)
+	}
+	if got := debug.SetGCPercent(150); got != 150 {
+		t.Errorf("gcPercent() changed the setting to %d", got)
+	}
+
+	debug.SetGCPercent(-1)
+	if got := gcPercent(); got != -1 {
+		t.Errorf("gcPercent() with GC off = %d, want -1", got)
+	}
+}
+
+func TestCheckGCEnabled(t *testing.T) {
+	if ok, detail := checkGCEnabled("", 100, false); !ok || !strings.Contains(detail, "GOGC=unset") {
+		t.Errorf("GC enabled: got %v, %q; want true and a detail naming GOGC=unset", ok, detail)
+	}
+
+	if ok, detail := checkGCEnabled("off", -1, false); ok || !strings.Contains(detail, "GOGC=off") {
+		t.Errorf("GC off: got %v, %q; want false and a detail naming GOGC=off", ok, detail)
+	}
+
+	if ok, _ := checkGCEnabled("off", -1, true); !ok {
+		t.Error("GC off with a memory limit should pass")
+	}
+}
+
+func TestGCEnabledCondition(t *testing.T) {
+	orig := debug.SetGCPercent(-1)
+	defer debug.SetGCPercent(orig)
+
+	result := GCEnabledCondition().evaluate()
+	if result.Passed {
+		t.Error("GCEnabledCondition should fail while GC is disabled")
+	}
+	if result.Detail == "" {
+		t.Error("GCEnabledCondition should explain its outcome")
+	}
+}
+
+func TestGCEnabledOrMemoryLimitCondition(t *testing.T) {
+	orig := debug.SetGCPercent(-1)
+	defer debug.SetGCPercent(orig)
+	origLimit := debug.SetMemoryLimit(1 << 40)
+	defer debug.SetMemoryLimit(origLimit)
+
+	if r := GCEnabledOrMemoryLimitCondition().evaluate(); !r.Passed {
+		t.Errorf("GCEnabledOrMemoryLimitCondition with a memory limit = %+v, want pass", r)
+	}
+	if r := GCEnabledCondition().evaluate(); r.Passed {
+		t.Error("GCEnabledCondition should fail with GC off even with a memory limit")
+	}
+}
@@ -2,4 +2,8 @@ module github.com/parthban-db/test-go-release
 
 go 1.21
 
-require golang.org/x/mod v0.14.0
+require (
+	golang.org/x/sys v0.30.0
+	golang.org/x/time v0.9.0
+	gopkg.in/yaml.v3 v3.0.1
+)
@@ -0,0 +1,65 @@
+package release
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"strings"
+)
+
+// RequiredGOARM returns the GOARM level (5, 6 or 7) the binary was built
+// for, from the GOARM build setting. known is false when the setting is
+// absent, e.g. for non-ARM builds or binaries without build info
+func RequiredGOARM() (level int, known bool) {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return 0, false
+	}
+	return goarmFromSettings(info.Settings)
+}
+
+// GOARMAtLeast reports whether the binary's GOARM level is at least
+// level. known is false when the level is not recorded
+func GOARMAtLeast(level int) (atLeast, known bool) {
+	got, known := RequiredGOARM()
+	if !known {
+		return false, false
+	}
+	return got >= level, true
+}
+
+// MinGOARMCondition returns a condition that fails when an ARM binary was
+// built for a GOARM level below level, e.g. a GOARM=5 soft-float build
+// destined for hardware that needs GOARM=7. Non-ARM builds pass; an ARM
+// build without the setting is an error
+func MinGOARMCondition(level int) Condition {
+	return newDetailedCondition("min-goarm", fmt.Sprintf("Built for GOARM %d or later", level), func() (bool, string, error) {
+		if runtime.GOARCH != "arm" {
+			return true, fmt.Sprintf("GOARM does not apply to %s builds", runtime.GOARCH), nil
+		}
+		got, known := RequiredGOARM()
+		if !known {
+			return false, "", errors.New("GOARM build setting not recorded")
+		}
+		return got >= level, fmt.Sprintf("built for GOARM %d, need %d", got, level), nil
+	})
+}
+
+// goarmFromSettings extracts the GOARM level from build settings. Go 1.22
+// and later may append a float mode, e.g. "7,softfloat"
+func goarmFromSettings(settings []debug.BuildSetting) (int, bool) {
+	for _, setting := range settings {
+		if setting.Key != "GOARM" {
+			continue
+		}
+		value, _, _ := strings.Cut(setting.Value, ",")
+		level, err := strconv.Atoi(value)
+		if err != nil {
+			return 0, false
+		}
+		return level, true
+	}
+	return 0, false
+}
@@ -0,0 +1,55 @@
+package release
+
+import (
+	"runtime"
+	"runtime/debug"
+	"testing"
+)
+
+func TestGOARMFromSettings(t *testing.T) {
+	tests := []struct {
+		value     string
+		want      int
+		wantKnown bool
+	}{
+		{"7", 7, true},
+		{"5", 5, true},
+		{"6,softfloat", 6, true},
+		{"7,hardfloat", 7, true},
+		{"", 0, false},
+		{"v7", 0, false},
+	}
+	for _, tt := range tests {
+		settings := []debug.BuildSetting{{Key: "GOOS", Value: "linux"}, {Key: "GOARM", Value: tt.value}}
+		got, known := goarmFromSettings(settings)
+		if got != tt.want || known != tt.wantKnown {
+			t.Errorf("goarmFromSettings(GOARM=%q) = %d, %v, want %d, %v", tt.value, got, known, tt.want, tt.wantKnown)
+		}
+	}
+	if _, known := goarmFromSettings(nil); known {
+		t.Error("goarmFromSettings(nil) should not be known")
+	}
+}
+
+func TestMinGOARMCondition(t *testing.T) {
+	if runtime.GOARCH != "arm" {
+		if _, known := RequiredGOARM(); known {
+			t.Error("RequiredGOARM() should not be known for non-ARM builds")
+		}
+		if r := MinGOARMCondition(7).evaluate(); !r.Passed {
+			t.Errorf("MinGOARMCondition(7) should pass on %s: %+v", runtime.GOARCH, r)
+		}
+		return
+	}
+
+	level, known := RequiredGOARM()
+	if !known {
+		t.Skip("GOARM build setting not recorded")
+	}
+	if ok, _ := GOARMAtLeast(level); !ok {
+		t.Errorf("GOARMAtLeast(%d) = false for a GOARM=%d build", level, level)
+	}
+	if r := MinGOARMCondition(level + 1).evaluate(); r.Passed {
+		t.Errorf("MinGOARMCondition(%d) should fail: %+v", level+1, r)
+	}
+}
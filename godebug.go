@@ -0,0 +1,69 @@
+package release
+
+import (
+	"fmt"
+	"os"
+	"runtime/debug"
+	"sort"
+	"strings"
+)
+
+// GoDebugSettings returns the GODEBUG settings in effect: the defaults
+// recorded in the binary at build time (from the go.mod go version and
+// //go:debug directives), overridden by the GODEBUG env var, e.g.
+// {"x509sha1": "1", "http2client": "0"}
+func GoDebugSettings() map[string]string {
+	var defaults string
+	if info, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range info.Settings {
+			if setting.Key == "DefaultGODEBUG" {
+				defaults = setting.Value
+			}
+		}
+	}
+	return goDebugSettings(defaults, os.Getenv("GODEBUG"))
+}
+
+// ForbiddenGoDebugCondition returns a condition that fails when any of the
+// forbidden GODEBUG settings is in effect (see GoDebugSettings). An entry
+// "key=value" matches that exact value, e.g. "x509sha1=1", while a bare
+// "key" matches any value. The detail lists the offending settings
+func ForbiddenGoDebugCondition(forbidden ...string) Condition {
+	return newDetailedCondition("forbidden-godebug", fmt.Sprintf("None of the GODEBUG settings %v is in effect", forbidden), func() (bool, string, error) {
+		active := forbiddenGoDebug(GoDebugSettings(), forbidden)
+		if len(active) == 0 {
+			return true, "", nil
+		}
+		return false, "forbidden GODEBUG settings in effect: " + strings.Join(active, ", "), nil
+	})
+}
+
+// goDebugSettings parses the build defaults and the GODEBUG env var, both
+// comma-separated key=value lists, with the env var taking precedence
+func goDebugSettings(defaults, env string) map[string]string {
+	settings := make(map[string]string)
+	for _, list := range []string{defaults, env} {
+		for _, entry := range strings.Split(list, ",") {
+			key, value, ok := strings.Cut(strings.TrimSpace(entry), "=")
+			if ok && key != "" {
+				settings[key] = value
+			}
+		}
+	}
+	return settings
+}
+
+// forbiddenGoDebug returns the settings matching a forbidden entry as
+// sorted "key=value" strings
+func forbiddenGoDebug(settings map[string]string, forbidden []string) []string {
+	var active []string
+	for _, f := range forbidden {
+		key, value, hasValue := strings.Cut(f, "=")
+		current, ok := settings[key]
+		if ok && (!hasValue || current == value) {
+			active = append(active, key+"="+current)
+		}
+	}
+	sort.Strings(active)
+	return active
+}
@@ -0,0 +1,57 @@
+package release
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGoDebugSettings(t *testing.T) {
+	got := goDebugSettings("httpmuxgo121=1,tlssha1=1,x509sha1=0", "x509sha1=1, http2client=0,bogus")
+	want := map[string]string{
+		"httpmuxgo121": "1",
+		"tlssha1":      "1",
+		"x509sha1":     "1",
+		"http2client":  "0",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("goDebugSettings() = %v, want %v", got, want)
+	}
+	if got := goDebugSettings("", ""); len(got) != 0 {
+		t.Errorf("goDebugSettings() with nothing set = %v, want empty", got)
+	}
+}
+
+func TestForbiddenGoDebug(t *testing.T) {
+	settings := map[string]string{"x509sha1": "1", "tlssha1": "0", "panicnil": "1"}
+	tests := []struct {
+		forbidden []string
+		want      []string
+	}{
+		{[]string{"x509sha1=1"}, []string{"x509sha1=1"}},
+		{[]string{"tlssha1=1"}, nil},
+		{[]string{"tlssha1", "x509sha1=1"}, []string{"tlssha1=0", "x509sha1=1"}},
+		{[]string{"madvdontneed"}, nil},
+	}
+	for _, tt := range tests {
+		if got := forbiddenGoDebug(settings, tt.forbidden); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("forbiddenGoDebug(%v) = %v, want %v", tt.forbidden, got, tt.want)
+		}
+	}
+}
+
+func TestForbiddenGoDebugCondition(t *testing.T) {
+	t.Setenv("GODEBUG", "x509sha1=1")
+	if got := GoDebugSettings()["x509sha1"]; got != "1" {
+		t.Errorf("GoDebugSettings()[x509sha1] = %q, want the GODEBUG value", got)
+	}
+
+	r := ForbiddenGoDebugCondition("x509sha1=1").evaluate()
+	if r.Passed || r.Detail != "forbidden GODEBUG settings in effect: x509sha1=1" {
+		t.Errorf("Unexpected result: %+v", r)
+	}
+
+	t.Setenv("GODEBUG", "x509sha1=0")
+	if r := ForbiddenGoDebugCondition("x509sha1=1").evaluate(); !r.Passed {
+		t.Errorf("x509sha1=0 should pass: %+v", r)
+	}
+}
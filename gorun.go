@@ -0,0 +1,55 @@
+package release
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// IsGoRun reports whether the running executable appears to have been
+// started via `go run`, i.e. from a go-build temporary directory
+//
+// The check is a heuristic based on the path returned by os.Executable:
+// the go command places temporary binaries under a go-build directory
+// (named go-build followed by digits) inside GOTMPDIR or the system temp
+// dir. Limitations:
+//   - binaries built by `go test` live in the same kind of directory and
+//     are therefore reported as go run
+//   - a regular binary deliberately copied into such a directory
+//     is a false positive
+//   - if os.Executable fails, IsGoRun returns false
+func IsGoRun() bool {
+	exe, err := os.Executable()
+	if err != nil {
+		return false
+	}
+	return isGoBuildPath(exe)
+}
+
+// isGoBuildPath checks whether any directory in path is a go-build temp dir
+func isGoBuildPath(path string) bool {
+	path = strings.ReplaceAll(path, `\`, "/")
+	elems := strings.Split(path, "/")
+	for _, elem := range elems[:len(elems)-1] {
+		suffix, ok := strings.CutPrefix(elem, "go-build")
+		if ok && suffix != "" && strings.Trim(suffix, "0123456789") == "" {
+			return true
+		}
+	}
+	return false
+}
+
+// NotGoRunCondition returns a condition that fails when the binary appears
+// to be running from a `go run` temporary directory (see IsGoRun)
+func NotGoRunCondition() Condition {
+	return newDetailedCondition("not-go-run", "Binary is not running from a go run temp directory", func() (bool, string, error) {
+		exe, err := os.Executable()
+		if err != nil {
+			return true, "executable path unavailable", nil
+		}
+		if isGoBuildPath(exe) {
+			return false, fmt.Sprintf("running from go-build temp dir: %s", exe), nil
+		}
+		return true, fmt.Sprintf("running from %s", exe), nil
+	})
+}
@@ -0,0 +1,48 @@
+package release
+
+import "testing"
+
+func TestIsGoBuildPath(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected bool
+	}{
+		{"/tmp/go-build1234567/b001/exe/main", true},
+		{"/var/folders/xy/T/go-build987/b001/exe/app", true},
+		{`C:\Users\me\AppData\Local\Temp\go-build42\b001\exe\app.exe`, true},
+		{"/usr/local/bin/app", false},
+		{"/opt/go-builder/app", false},
+		{"/home/user/go-build", false},
+		{"app", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if result := isGoBuildPath(tt.path); result != tt.expected {
+				t.Errorf("isGoBuildPath(%s) = %v, want %v", tt.path, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIsGoRun(t *testing.T) {
+	// Test binaries are built into a go-build temp dir, just like go run
+	if !IsGoRun() {
+		t.Log("IsGoRun returned false for a test binary (custom GOTMPDIR?)")
+	}
+}
+
+func TestNotGoRunCondition(t *testing.T) {
+	cond := NotGoRunCondition()
+	if cond.Name == "" || cond.Check == nil {
+		t.Fatal("NotGoRunCondition returned an incomplete condition")
+	}
+
+	passed, err := cond.Check()
+	if err != nil {
+		t.Errorf("Check() error = %v", err)
+	}
+	if passed != !IsGoRun() {
+		t.Errorf("Check() = %v, want %v", passed, !IsGoRun())
+	}
+}
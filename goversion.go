@@ -0,0 +1,105 @@
+package release
+
+import (
+	"cmp"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// GoVersion is a parsed Go version such as go1.21.5 or go1.22rc1
+type GoVersion struct {
+	Major int
+	Minor int
+	Patch int
+
+	// Prerelease is the pre-release tag, e.g. "rc1" or "beta2", or ""
+	// for a release. Go pre-releases precede the .0 release of their
+	// minor version
+	Prerelease string
+}
+
+// ParseGoVersion parses a Go version. It accepts the "go" prefix of
+// runtime.Version, the "v" prefix of semver or neither, a missing minor
+// or patch ("go1.21" is 1.21.0 and "v2" is 2.0.0) and rc and beta
+// suffixes, in Go ("go1.22rc1") or semver ("v1.22.0-rc1") form. A suffix
+// after a space, such as " X:boringcrypto", is ignored
+func ParseGoVersion(s string) (GoVersion, error) {
+	var v GoVersion
+	version, _, _ := strings.Cut(strings.TrimSpace(s), " ")
+	if trimmed, ok := strings.CutPrefix(version, "go"); ok {
+		version = trimmed
+	} else {
+		version = strings.TrimPrefix(version, "v")
+	}
+
+	if i := strings.IndexFunc(version, func(r rune) bool { return r != '.' && (r < '0' || r > '9') }); i >= 0 {
+		version, v.Prerelease = version[:i], strings.TrimPrefix(version[i:], "-")
+		if _, _, ok := splitPrerelease(v.Prerelease); !ok {
+			return GoVersion{}, fmt.Errorf("invalid Go version %q: unknown suffix %q", s, v.Prerelease)
+		}
+	}
+
+	parts := strings.Split(version, ".")
+	if len(parts) > 3 {
+		return GoVersion{}, fmt.Errorf("invalid Go version %q: want major[.minor[.patch]]", s)
+	}
+	nums := []*int{&v.Major, &v.Minor, &v.Patch}
+	for i, part := range parts {
+		n, err := parseVersionNumber(part)
+		if err != nil {
+			return GoVersion{}, fmt.Errorf("invalid Go version %q: %w", s, err)
+		}
+		*nums[i] = n
+	}
+	return v, nil
+}
+
+// splitPrerelease splits a pre-release tag such as "rc1" into its kind
+// and number
+func splitPrerelease(pre string) (kind string, n int, ok bool) {
+	for _, kind := range []string{"beta", "rc"} {
+		if digits, found := strings.CutPrefix(pre, kind); found && digits != "" && strings.Trim(digits, "0123456789") == "" {
+			n, err := strconv.Atoi(digits)
+			return kind, n, err == nil
+		}
+	}
+	return "", 0, false
+}
+
+// Compare returns -1, 0 or 1 as v is older than, the same as or newer
+// than other. A pre-release is older than the release of the same
+// version, betas are older than release candidates, and pre-release
+// numbers are compared numerically, so rc10 is newer than rc9
+func (v GoVersion) Compare(other GoVersion) int {
+	for _, pair := range [][2]int{{v.Major, other.Major}, {v.Minor, other.Minor}, {v.Patch, other.Patch}} {
+		if c := cmp.Compare(pair[0], pair[1]); c != 0 {
+			return c
+		}
+	}
+
+	switch {
+	case v.Prerelease == other.Prerelease:
+		return 0
+	case v.Prerelease == "":
+		return 1
+	case other.Prerelease == "":
+		return -1
+	}
+	kind, n, _ := splitPrerelease(v.Prerelease)
+	otherKind, otherN, _ := splitPrerelease(other.Prerelease)
+	if c := strings.Compare(kind, otherKind); c != 0 {
+		return c
+	}
+	return cmp.Compare(n, otherN)
+}
+
+// String returns the version as Go spells it, e.g. "go1.21.5", or
+// "go1.22rc1" for a pre-release of a .0 release. A missing patch is
+// written as .0, so "go1.21" becomes "go1.21.0"
+func (v GoVersion) String() string {
+	if v.Prerelease != "" && v.Patch == 0 {
+		return fmt.Sprintf("go%d.%d%s", v.Major, v.Minor, v.Prerelease)
+	}
+	return fmt.Sprintf("go%d.%d.%d%s", v.Major, v.Minor, v.Patch, v.Prerelease)
+}
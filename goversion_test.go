@@ -0,0 +1,102 @@
+package release
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseGoVersion(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    GoVersion
+		wantErr bool
+	}{
+		{"go1.21.0", GoVersion{1, 21, 0, ""}, false},
+		{"1.21.0", GoVersion{1, 21, 0, ""}, false},
+		{"v1.21.0", GoVersion{1, 21, 0, ""}, false},
+		{"go1.20", GoVersion{1, 20, 0, ""}, false},
+		{" go1.21.0\n", GoVersion{1, 21, 0, ""}, false},
+		{"go1.22rc1", GoVersion{1, 22, 0, "rc1"}, false},
+		{"go1.21beta2", GoVersion{1, 21, 0, "beta2"}, false},
+		{"go1.21.0rc1", GoVersion{1, 21, 0, "rc1"}, false},
+		{"v1.22.0-rc1", GoVersion{1, 22, 0, "rc1"}, false},
+		{"go1.21.0 X:boringcrypto", GoVersion{1, 21, 0, ""}, false},
+		{"", GoVersion{}, true},
+		{"go1", GoVersion{1, 0, 0, ""}, false},
+		{"2", GoVersion{2, 0, 0, ""}, false},
+		{"v99", GoVersion{99, 0, 0, ""}, false},
+		{"go", GoVersion{}, true},
+		{"go1.2.3.4.5", GoVersion{}, true},
+		{"go1.22alpha1", GoVersion{}, true},
+		{"go1.22rc", GoVersion{}, true},
+		{"go1.x", GoVersion{}, true},
+		{"go1.22.rc1", GoVersion{}, true},
+		{"devel go1.23-abc123", GoVersion{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseGoVersion(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseGoVersion(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseGoVersion(%q) = %+v, want %+v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGoVersionCompare(t *testing.T) {
+	// In ascending order
+	ordered := []string{"go1.20", "go1.21beta1", "go1.21rc1", "go1.21rc2", "go1.21rc10", "go1.21.0", "go1.21.1", "go1.21.10", "go1.22.0", "go2.0"}
+
+	var versions []GoVersion
+	for _, s := range ordered {
+		v, err := ParseGoVersion(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		versions = append(versions, v)
+	}
+	for i, v := range versions {
+		if got := v.Compare(v); got != 0 {
+			t.Errorf("%v.Compare(itself) = %d", v, got)
+		}
+		if i > 0 {
+			if got := versions[i-1].Compare(v); got != -1 {
+				t.Errorf("%v.Compare(%v) = %d, want -1", versions[i-1], v, got)
+			}
+			if got := v.Compare(versions[i-1]); got != 1 {
+				t.Errorf("%v.Compare(%v) = %d, want 1", v, versions[i-1], got)
+			}
+		}
+	}
+
+	shuffled := slices.Clone(versions)
+	slices.Reverse(shuffled)
+	slices.SortFunc(shuffled, GoVersion.Compare)
+	if !slices.Equal(shuffled, versions) {
+		t.Errorf("sorted versions = %v, want %v", shuffled, versions)
+	}
+}
+
+func TestGoVersionString(t *testing.T) {
+	tests := []struct {
+		v    GoVersion
+		want string
+	}{
+		{GoVersion{1, 21, 5, ""}, "go1.21.5"},
+		{GoVersion{1, 21, 0, ""}, "go1.21.0"},
+		{GoVersion{1, 22, 0, "rc1"}, "go1.22rc1"},
+		{GoVersion{1, 22, 1, "rc1"}, "go1.22.1rc1"},
+	}
+	for _, tt := range tests {
+		if got := tt.v.String(); got != tt.want {
+			t.Errorf("%+v.String() = %q, want %q", tt.v, got, tt.want)
+		}
+		if parsed, err := ParseGoVersion(tt.want); err != nil || parsed != tt.v {
+			t.Errorf("ParseGoVersion(%q) = %+v, %v; want %+v", tt.want, parsed, err, tt.v)
+		}
+	}
+}
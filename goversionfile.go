@@ -0,0 +1,64 @@
+package release
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// DefaultGoVersionFile is the file read by ReadGoVersionFile when no path
+// is given, as used by goenv and asdf
+const DefaultGoVersionFile = ".go-version"
+
+// ReadGoVersionFile returns the Go version pinned in a .go-version file,
+// e.g. "1.21.5". Surrounding whitespace, a "go" prefix and comment lines
+// are ignored. An empty path reads DefaultGoVersionFile in the working
+// directory
+func ReadGoVersionFile(path string) (string, error) {
+	if path == "" {
+		path = DefaultGoVersionFile
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	version, err := parseGoVersionFile(data)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", path, err)
+	}
+	return version, nil
+}
+
+// SatisfiesGoVersionFile reports whether the running Go version is at
+// least the version pinned in the .go-version file at path (see
+// ReadGoVersionFile)
+func SatisfiesGoVersionFile(path string) (bool, error) {
+	version, err := ReadGoVersionFile(path)
+	if err != nil {
+		return false, err
+	}
+	return IsGoVersionAtLeast(version)
+}
+
+// parseGoVersionFile extracts the version from .go-version contents
+func parseGoVersionFile(data []byte) (string, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		version := strings.TrimPrefix(line, "go")
+		if _, err := ParseGoVersion(version); err != nil {
+			return "", fmt.Errorf("invalid Go version %q: %w", line, err)
+		}
+		return version, nil
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	return "", errors.New("no Go version found")
+}
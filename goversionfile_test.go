@@ -0,0 +1,66 @@
+package release
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseGoVersionFile(t *testing.T) {
+	tests := []struct {
+		data    string
+		want    string
+		wantErr bool
+	}{
+		{"1.21.5\n", "1.21.5", false},
+		{"  go1.22 \r\n", "1.22", false},
+		{"# pinned for CI\n\n1.20rc1\n", "1.20rc1", false},
+		{"", "", true},
+		{"latest\n", "", true},
+	}
+	for _, tt := range tests {
+		got, err := parseGoVersionFile([]byte(tt.data))
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseGoVersionFile(%q) error = %v, wantErr %v", tt.data, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseGoVersionFile(%q) = %q, want %q", tt.data, got, tt.want)
+		}
+	}
+}
+
+func TestSatisfiesGoVersionFile(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	if ok, err := SatisfiesGoVersionFile(write("old", "1.10\n")); !ok || err != nil {
+		t.Errorf("SatisfiesGoVersionFile(1.10) = %v, %v, want true", ok, err)
+	}
+	if ok, err := SatisfiesGoVersionFile(write("future", "99.0.0\n")); ok || err != nil {
+		t.Errorf("SatisfiesGoVersionFile(99.0.0) = %v, %v, want false", ok, err)
+	}
+	if _, err := SatisfiesGoVersionFile(filepath.Join(dir, "missing")); err == nil {
+		t.Error("SatisfiesGoVersionFile() on a missing file should fail")
+	}
+
+	// The default path is relative to the working directory
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	write(DefaultGoVersionFile, "1.21.5\n")
+	if got, err := ReadGoVersionFile(""); got != "1.21.5" || err != nil {
+		t.Errorf("ReadGoVersionFile(\"\") = %q, %v, want 1.21.5", got, err)
+	}
+}
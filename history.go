@@ -0,0 +1,76 @@
+package release
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// HistoricalRun is the aggregate of a single condition run as recorded
+// in an NDJSON history log
+type HistoricalRun struct {
+	SchemaVersion string    `json:"schema_version"`
+	Timestamp     time.Time `json:"timestamp"`
+	Total         int       `json:"total"`
+	Passed        int       `json:"passed"`
+	Failed        int       `json:"failed"`
+	AllPassed     bool      `json:"all_passed"`
+	Failures      []string  `json:"failures,omitempty"`
+}
+
+// now is the clock used for history timestamps, replaceable in tests
+var now = time.Now
+
+// summary computes the aggregate of the results, timestamped with the
+// current time
+func (results TestResults) summary() HistoricalRun {
+	run := HistoricalRun{
+		SchemaVersion: SchemaVersion,
+		Timestamp:     now().UTC(),
+		Total:         len(results),
+		AllPassed:     results.AllPassed(),
+	}
+	for _, r := range results {
+		if r.OK() {
+			run.Passed++
+		} else {
+			run.Failed++
+			run.Failures = append(run.Failures, r.Name)
+		}
+	}
+	return run
+}
+
+// AppendTo writes the aggregate of the results to w as a single NDJSON
+// record, suitable for an append-only history file
+func (results TestResults) AppendTo(w io.Writer) error {
+	data, err := json.Marshal(results.summary())
+	if err != nil {
+		return fmt.Errorf("encoding history record: %w", err)
+	}
+	data = append(data, '\n')
+	if _, err := w.Write(data); err != nil {
+		return fmt.Errorf("writing history record: %w", err)
+	}
+	return nil
+}
+
+// LoadHistory reads all NDJSON records written by AppendTo from r, in the
+// order they were written
+func LoadHistory(r io.Reader) ([]HistoricalRun, error) {
+	var runs []HistoricalRun
+	dec := json.NewDecoder(r)
+	for {
+		var run HistoricalRun
+		err := dec.Decode(&run)
+		if errors.Is(err, io.EOF) {
+			return runs, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid history record %d: %w", len(runs)+1, err)
+		}
+		runs = append(runs, run)
+	}
+}
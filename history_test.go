@@ -0,0 +1,67 @@
+package release
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAppendToAndLoadHistory(t *testing.T) {
+	fixed := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	now = func() time.Time { return fixed }
+	defer func() { now = time.Now }()
+
+	var buf bytes.Buffer
+	passing := TestResults{{Name: "a", Passed: true}, {Name: "b", Passed: true}}
+	failing := TestResults{
+		{Name: "a", Passed: true},
+		{Name: "b", Passed: false},
+		{Name: "c", Passed: true, Error: errors.New("boom")},
+	}
+
+	if err := passing.AppendTo(&buf); err != nil {
+		t.Fatalf("AppendTo() error = %v", err)
+	}
+	if err := failing.AppendTo(&buf); err != nil {
+		t.Fatalf("AppendTo() error = %v", err)
+	}
+
+	if lines := strings.Count(buf.String(), "\n"); lines != 2 {
+		t.Fatalf("Expected 2 NDJSON lines, got %d", lines)
+	}
+
+	runs, err := LoadHistory(&buf)
+	if err != nil {
+		t.Fatalf("LoadHistory() error = %v", err)
+	}
+	if len(runs) != 2 {
+		t.Fatalf("Expected 2 runs, got %d", len(runs))
+	}
+
+	if !runs[0].AllPassed || runs[0].Passed != 2 || runs[0].Failed != 0 {
+		t.Errorf("Unexpected first run: %+v", runs[0])
+	}
+	if runs[1].AllPassed || runs[1].Total != 3 || runs[1].Failed != 2 {
+		t.Errorf("Unexpected second run: %+v", runs[1])
+	}
+	if strings.Join(runs[1].Failures, ",") != "b,c" {
+		t.Errorf("Failures = %v, want [b c]", runs[1].Failures)
+	}
+	if !runs[1].Timestamp.Equal(fixed) {
+		t.Errorf("Timestamp = %v, want %v", runs[1].Timestamp, fixed)
+	}
+}
+
+func TestLoadHistoryInvalid(t *testing.T) {
+	input := `{"timestamp":"2024-03-01T12:00:00Z","total":1,"passed":1}` + "\nnot json\n"
+	if _, err := LoadHistory(strings.NewReader(input)); err == nil {
+		t.Error("LoadHistory should fail on a malformed record")
+	}
+
+	runs, err := LoadHistory(strings.NewReader(""))
+	if err != nil || len(runs) != 0 {
+		t.Errorf("LoadHistory(empty) = %v, %v; want no runs and no error", runs, err)
+	}
+}
@@ -0,0 +1,32 @@
+package release
+
+import "fmt"
+
+// FreeInodes returns the number of free inodes on the filesystem holding
+// path. It returns ErrNotSupported on platforms other than Linux and
+// darwin
+func FreeInodes(path string) (uint64, error) {
+	free, _, err := statfsInodes(path)
+	return free, err
+}
+
+// MinInodesCondition returns a condition that fails when the filesystem
+// holding path has fewer than n free inodes. Filesystems that allocate
+// inodes dynamically (reporting no inode total, e.g. btrfs) pass
+func MinInodesCondition(path string, n uint64) Condition {
+	return newDetailedCondition("min-inodes", fmt.Sprintf("At least %d free inodes on %s", n, path), func() (bool, string, error) {
+		free, total, err := statfsInodes(path)
+		if err != nil {
+			return false, "", err
+		}
+		return checkInodes(free, total, n)
+	})
+}
+
+// checkInodes applies the MinInodesCondition threshold to statfs counts
+func checkInodes(free, total, n uint64) (bool, string, error) {
+	if total == 0 {
+		return true, "filesystem does not limit inodes", nil
+	}
+	return free >= n, fmt.Sprintf("%d of %d inodes free, need %d", free, total, n), nil
+}
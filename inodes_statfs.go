@@ -0,0 +1,15 @@
+//go:build linux || darwin
+
+package release
+
+import "golang.org/x/sys/unix"
+
+// statfsInodes returns the free and total inode counts of the filesystem
+// holding path
+func statfsInodes(path string) (free, total uint64, err error) {
+	var st unix.Statfs_t
+	if err := unix.Statfs(path, &st); err != nil {
+		return 0, 0, err
+	}
+	return uint64(st.Ffree), uint64(st.Files), nil
+}
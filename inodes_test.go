@@ -0,0 +1,41 @@
+package release
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestFreeInodes(t *testing.T) {
+	free, err := FreeInodes(t.TempDir())
+	if errors.Is(err, ErrNotSupported) {
+		t.Skip("inode counts not supported on this platform")
+	}
+	if err != nil {
+		t.Fatalf("FreeInodes() error = %v", err)
+	}
+	t.Logf("Free inodes: %d", free)
+
+	if r := MinInodesCondition(t.TempDir(), 0).evaluate(); !r.Passed {
+		t.Errorf("MinInodesCondition(0) = %+v", r)
+	}
+	if _, err := FreeInodes("/does/not/exist"); err == nil {
+		t.Error("FreeInodes() on a missing path should fail")
+	}
+}
+
+func TestCheckInodes(t *testing.T) {
+	tests := []struct {
+		free, total, n uint64
+		want           bool
+	}{
+		{free: 100, total: 1000, n: 50, want: true},
+		{free: 100, total: 1000, n: 100, want: true},
+		{free: 0, total: 1000, n: 1, want: false},
+		{free: 0, total: 0, n: 1, want: true},
+	}
+	for _, tt := range tests {
+		if got, detail, _ := checkInodes(tt.free, tt.total, tt.n); got != tt.want {
+			t.Errorf("checkInodes(%d, %d, %d) = %v (%s), want %v", tt.free, tt.total, tt.n, got, detail, tt.want)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+//go:build go1.23
+
+package release
+
+import "iter"
+
+// All returns an iterator over the results in order
+func (results TestResults) All() iter.Seq[TestResult] {
+	return func(yield func(TestResult) bool) {
+		for _, r := range results {
+			if !yield(r) {
+				return
+			}
+		}
+	}
+}
+
+// Failures returns an iterator over the results that are not OK (see
+// TestResult.OK), in order. It is the iterator form of FailedResults
+func (results TestResults) Failures() iter.Seq[TestResult] {
+	return func(yield func(TestResult) bool) {
+		for _, r := range results {
+			if !r.OK() && !yield(r) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,43 @@
+//go:build go1.23
+
+package release
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestResultsIterators(t *testing.T) {
+	results := TestResults{
+		{Name: "a", Passed: true},
+		{Name: "b", Passed: false},
+		{Name: "c", Error: errors.New("boom"), ErrorIsPass: true},
+		{Name: "d", Error: errors.New("boom")},
+	}
+
+	var all []string
+	for r := range results.All() {
+		all = append(all, r.Name)
+	}
+	if len(all) != 4 || all[0] != "a" || all[3] != "d" {
+		t.Errorf("All() yielded %v", all)
+	}
+
+	var failed []string
+	for r := range results.Failures() {
+		failed = append(failed, r.Name)
+	}
+	if len(failed) != 2 || failed[0] != "b" || failed[1] != "d" {
+		t.Errorf("Failures() yielded %v, want [b d]", failed)
+	}
+
+	// Breaking out of the loop stops the iteration
+	n := 0
+	for range results.Failures() {
+		n++
+		break
+	}
+	if n != 1 {
+		t.Errorf("Expected a single iteration, got %d", n)
+	}
+}
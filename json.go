@@ -0,0 +1,128 @@
+package release
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// SchemaVersion is the version of the JSON documents written by the
+// package, recorded in their top-level "schema_version" field: BuildInfo,
+// ReadinessReport, the WriteJSON report and history records. It changes
+// when fields are removed or change meaning, not when fields are added
+const SchemaVersion = "1"
+
+// MarshalJSON encodes the build info with a top-level schema_version
+func (info BuildInfo) MarshalJSON() ([]byte, error) {
+	type plain BuildInfo
+	return json.Marshal(struct {
+		SchemaVersion string `json:"schema_version"`
+		plain
+	}{SchemaVersion, plain(info)})
+}
+
+// nestedBuildInfo encodes a BuildInfo without its schema_version, for
+// documents that record it once at the top level
+type nestedBuildInfo BuildInfo
+
+// testResultJSON is the serialized form of a TestResult
+type testResultJSON struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Passed      bool   `json:"passed"`
+	Detail      string `json:"detail,omitempty"`
+	Error       string `json:"error,omitempty"`
+	ErrorIsPass bool   `json:"error_is_pass,omitempty"`
+	Skipped     bool   `json:"skipped,omitempty"`
+	SkipReason  string `json:"skip_reason,omitempty"`
+	StartedAt   string `json:"started_at,omitempty"`
+	DurationNS  int64  `json:"duration_ns,omitempty"`
+	ExitCode    int    `json:"exit_code,omitempty"`
+
+	Metrics map[string]any `json:"metrics,omitempty"`
+}
+
+// formatTimestamp renders t as UTC RFC 3339 with nanoseconds, so
+// serialized reports do not depend on the host's timezone. The zero time
+// renders as ""
+func formatTimestamp(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+// parseTimestamp parses a timestamp written by formatTimestamp
+func parseTimestamp(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339Nano, s)
+}
+
+// MarshalJSON encodes the result with its error as a string
+func (r TestResult) MarshalJSON() ([]byte, error) {
+	return json.Marshal(newTestResultJSON(r))
+}
+
+// newTestResultJSON converts r to its serialized form
+func newTestResultJSON(r TestResult) testResultJSON {
+	v := testResultJSON{
+		Name:        r.Name,
+		Description: r.Description,
+		Passed:      r.Passed,
+		Detail:      r.Detail,
+		ErrorIsPass: r.ErrorIsPass,
+		Skipped:     r.Skipped,
+		SkipReason:  r.SkipReason,
+		StartedAt:   formatTimestamp(r.StartedAt),
+		DurationNS:  int64(r.Duration),
+		ExitCode:    r.ExitCode,
+		Metrics:     r.Metrics,
+	}
+	if r.Error != nil {
+		v.Error = r.Error.Error()
+	}
+	return v
+}
+
+// UnmarshalJSON decodes a result encoded by MarshalJSON. The error, if
+// any, is restored as a plain error carrying the original message.
+// Metrics are restored with JSON types, so numbers become float64
+func (r *TestResult) UnmarshalJSON(data []byte) error {
+	var v testResultJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	result, err := v.result()
+	if err != nil {
+		return err
+	}
+	*r = result
+	return nil
+}
+
+// result converts the serialized form back to a TestResult
+func (v testResultJSON) result() (TestResult, error) {
+	startedAt, err := parseTimestamp(v.StartedAt)
+	if err != nil {
+		return TestResult{}, err
+	}
+	r := TestResult{
+		Name:        v.Name,
+		Description: v.Description,
+		Passed:      v.Passed,
+		Detail:      v.Detail,
+		ErrorIsPass: v.ErrorIsPass,
+		Skipped:     v.Skipped,
+		SkipReason:  v.SkipReason,
+		StartedAt:   startedAt,
+		Duration:    time.Duration(v.DurationNS),
+		ExitCode:    v.ExitCode,
+		Metrics:     v.Metrics,
+	}
+	if v.Error != "" {
+		r.Error = errors.New(v.Error)
+	}
+	return r, nil
+}
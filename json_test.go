@@ -0,0 +1,112 @@
+package release
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTestResultJSONRoundTrip(t *testing.T) {
+	results := TestResults{
+		{Name: "ok", Description: "Passes", Passed: true, Detail: "fine"},
+		{Name: "broken", Error: errors.New("permission denied"), ErrorIsPass: true, ExitCode: 10},
+		{Name: "skipped", Skipped: true, SkipReason: "skipped via SkipChecks"},
+	}
+
+	data, err := json.Marshal(results)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if !strings.Contains(string(data), `"error":"permission denied"`) {
+		t.Errorf("Error should be encoded as a string: %s", data)
+	}
+
+	var decoded TestResults
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if len(decoded) != 3 {
+		t.Fatalf("Expected 3 results, got %d", len(decoded))
+	}
+	if decoded[0].Detail != "fine" || !decoded[0].Passed {
+		t.Errorf("Unexpected first result: %+v", decoded[0])
+	}
+	if decoded[1].Error == nil || decoded[1].Error.Error() != "permission denied" || !decoded[1].ErrorIsPass || decoded[1].ExitCode != 10 {
+		t.Errorf("Unexpected second result: %+v", decoded[1])
+	}
+	if !decoded[2].Skipped || decoded[2].SkipReason == "" {
+		t.Errorf("Unexpected third result: %+v", decoded[2])
+	}
+	if decoded.AllPassed() != results.AllPassed() {
+		t.Error("Round trip changed the verdict")
+	}
+}
+
+func TestTestResultJSONTimestampUTC(t *testing.T) {
+	zone := time.FixedZone("UTC+5", 5*60*60)
+	started := time.Date(2024, 3, 1, 17, 0, 0, 0, zone)
+	r := TestResult{Name: "timed", Passed: true, StartedAt: started, Duration: 1500 * time.Millisecond}
+
+	data, err := json.Marshal(r)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if !strings.Contains(string(data), `"started_at":"2024-03-01T12:00:00Z"`) {
+		t.Errorf("StartedAt should be encoded in UTC: %s", data)
+	}
+
+	var decoded TestResult
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if !decoded.StartedAt.Equal(started) || decoded.Duration != r.Duration {
+		t.Errorf("Round trip = %v, %v, want %v, %v", decoded.StartedAt, decoded.Duration, started, r.Duration)
+	}
+}
+
+func TestSchemaVersion(t *testing.T) {
+	documents := map[string]func(*bytes.Buffer) error{
+		"BuildInfo": func(buf *bytes.Buffer) error {
+			return json.NewEncoder(buf).Encode(GetBuildInfo())
+		},
+		"ReadinessReport": func(buf *bytes.Buffer) error {
+			return NewConditionSet().GenerateReport().Encode(buf)
+		},
+		"WriteJSON": func(buf *bytes.Buffer) error {
+			return TestResults{{Name: "ok", Passed: true}}.WriteJSON(buf)
+		},
+		"AppendTo": func(buf *bytes.Buffer) error {
+			return TestResults{{Name: "ok", Passed: true}}.AppendTo(buf)
+		},
+	}
+	for name, write := range documents {
+		var buf bytes.Buffer
+		if err := write(&buf); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		var doc map[string]any
+		if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+			t.Fatalf("%s: invalid JSON: %v", name, err)
+		}
+		if doc["schema_version"] != SchemaVersion {
+			t.Errorf("%s: schema_version = %v, want %q", name, doc["schema_version"], SchemaVersion)
+		}
+		if info, ok := doc["build_info"].(map[string]any); ok {
+			if _, nested := info["schema_version"]; nested {
+				t.Errorf("%s: schema_version repeated in build_info", name)
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(&BuildInfo{GoVersion: "go1.21.0", NumCPU: 4}); err != nil {
+		t.Fatal(err)
+	}
+	var info BuildInfo
+	if err := json.Unmarshal(buf.Bytes(), &info); err != nil || info.GoVersion != "go1.21.0" || info.NumCPU != 4 {
+		t.Errorf("BuildInfo round trip = %+v, %v", info, err)
+	}
+}
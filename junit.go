@@ -0,0 +1,136 @@
+package release
+
+import (
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// junitSuite is the <testsuite> element written by ToJUnit
+type junitSuite struct {
+	XMLName    xml.Name         `xml:"testsuite"`
+	Name       string           `xml:"name,attr"`
+	Tests      int              `xml:"tests,attr"`
+	Failures   int              `xml:"failures,attr"`
+	Skipped    int              `xml:"skipped,attr"`
+	Time       string           `xml:"time,attr,omitempty"`
+	Timestamp  string           `xml:"timestamp,attr,omitempty"`
+	Properties *junitProperties `xml:"properties,omitempty"`
+	Cases      []junitCase      `xml:"testcase"`
+	SystemErr  string           `xml:"system-err,omitempty"`
+}
+
+// junitProperties is the suite's <properties> element
+type junitProperties struct {
+	Property []junitProperty `xml:"property"`
+}
+
+// junitProperty is a <property> of the suite
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+// junitCase is a <testcase> element
+type junitCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr,omitempty"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+// junitMessage is a <failure> or <skipped> element
+type junitMessage struct {
+	Message string `xml:"message,attr,omitempty"`
+}
+
+// ToJUnit renders the results as a JUnit XML <testsuite> with one
+// <testcase> per condition, so release gates show up in CI dashboards
+// alongside unit tests. Failed conditions and errors on required
+// conditions become <failure> elements, skipped conditions <skipped>
+// elements. Errors on advisory conditions, other than panics, pass and
+// are reported in the case's <system-out>, and listed as warnings in the
+// suite's <system-err>, one "warning: name (error)" line each. Durations
+// and the UTC start time are included when the results carry timings
+func (results TestResults) ToJUnit(suiteName string) ([]byte, error) {
+	return results.toJUnit(suiteName, nil)
+}
+
+// toJUnit renders the suite with properties sorted by name
+func (results TestResults) toJUnit(suiteName string, properties map[string]string) ([]byte, error) {
+	suite := junitSuite{Name: suiteName, Tests: len(results)}
+	if len(properties) > 0 {
+		props := &junitProperties{}
+		for name, value := range properties {
+			props.Property = append(props.Property, junitProperty{Name: name, Value: value})
+		}
+		sort.Slice(props.Property, func(i, j int) bool {
+			return props.Property[i].Name < props.Property[j].Name
+		})
+		suite.Properties = props
+	}
+	if start, end := results.timeSpan(); !start.IsZero() {
+		suite.Time = junitSeconds(end.Sub(start))
+		suite.Timestamp = start.UTC().Format("2006-01-02T15:04:05")
+	}
+
+	for _, r := range results {
+		c := junitCase{Name: r.Name, ClassName: suiteName, SystemOut: r.Detail}
+		if !r.StartedAt.IsZero() {
+			c.Time = junitSeconds(r.Duration)
+		}
+		switch {
+		case r.Skipped:
+			c.Skipped = &junitMessage{Message: r.SkipReason}
+			suite.Skipped++
+		case r.Error != nil && !r.OK():
+			c.Failure = &junitMessage{Message: "error: " + r.Error.Error()}
+			suite.Failures++
+		case r.Error != nil:
+			c.SystemOut = joinDetail(r.Detail, "advisory error: "+r.Error.Error())
+		case !r.Passed:
+			c.Failure = &junitMessage{Message: failureMessage(r)}
+			suite.Failures++
+		}
+		suite.Cases = append(suite.Cases, c)
+	}
+	for _, r := range results.Warnings() {
+		suite.SystemErr += "warning: " + describeFailure(r) + "\n"
+	}
+
+	data, err := xml.MarshalIndent(suite, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), append(data, '\n')...), nil
+}
+
+// junitSeconds formats a duration as fractional seconds
+func junitSeconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
+
+// failureMessage summarizes a failed condition for a <failure> message
+func failureMessage(r TestResult) string {
+	if r.Detail != "" {
+		return r.Detail
+	}
+	if r.Description != "" {
+		return "condition not met: " + r.Description
+	}
+	return "condition not met"
+}
+
+// joinDetail joins two explanations with "; ", skipping empty ones
+func joinDetail(a, b string) string {
+	switch {
+	case a == "":
+		return b
+	case b == "":
+		return a
+	}
+	return a + "; " + b
+}
@@ -0,0 +1,59 @@
+package release
+
+import (
+	"encoding/xml"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestToJUnit(t *testing.T) {
+	started := time.Date(2024, 3, 1, 17, 0, 0, 0, time.FixedZone("UTC+5", 5*60*60))
+	results := TestResults{
+		{Name: "go-version", Passed: true, StartedAt: started, Duration: 1500 * time.Millisecond},
+		{Name: "platform", Description: "Linux only", Detail: "running on plan9", StartedAt: started, Duration: time.Millisecond},
+		{Name: "db", Error: errors.New("connection refused")},
+		{Name: "dns", Error: errors.New("timeout"), ErrorIsPass: true},
+		{Name: "proc", Skipped: true, SkipReason: "skipped via SkipChecks"},
+	}
+
+	data, err := results.ToJUnit("release")
+	if err != nil {
+		t.Fatalf("ToJUnit() error = %v", err)
+	}
+	if !strings.HasPrefix(string(data), xml.Header) {
+		t.Errorf("ToJUnit() output should start with the XML header:\n%s", data)
+	}
+
+	var suite junitSuite
+	if err := xml.Unmarshal(data, &suite); err != nil {
+		t.Fatalf("ToJUnit() produced invalid XML: %v\n%s", err, data)
+	}
+	if suite.Name != "release" || suite.Tests != 5 || suite.Failures != 2 || suite.Skipped != 1 {
+		t.Errorf("Unexpected suite counts: %+v", suite)
+	}
+	if suite.Timestamp != "2024-03-01T12:00:00" || suite.Time != "1.500" {
+		t.Errorf("Suite timing = %s, %s, want 2024-03-01T12:00:00, 1.500", suite.Timestamp, suite.Time)
+	}
+
+	cases := suite.Cases
+	if cases[0].Failure != nil || cases[0].Time != "1.500" {
+		t.Errorf("Unexpected passing case: %+v", cases[0])
+	}
+	if cases[1].Failure == nil || cases[1].Failure.Message != "running on plan9" {
+		t.Errorf("Failed condition should report its detail: %+v", cases[1])
+	}
+	if cases[2].Failure == nil || !strings.Contains(cases[2].Failure.Message, "connection refused") || cases[2].Time != "" {
+		t.Errorf("Errored required condition should fail without a time: %+v", cases[2])
+	}
+	if cases[3].Failure != nil || !strings.Contains(cases[3].SystemOut, "timeout") {
+		t.Errorf("Advisory error should pass with output: %+v", cases[3])
+	}
+	if cases[4].Skipped == nil || cases[4].Skipped.Message != "skipped via SkipChecks" {
+		t.Errorf("Unexpected skipped case: %+v", cases[4])
+	}
+	if suite.SystemErr != "warning: dns (timeout)\n" {
+		t.Errorf("Suite should list advisory errors as warnings, got %q", suite.SystemErr)
+	}
+}
@@ -0,0 +1,28 @@
+package release
+
+import (
+	"os"
+	"strings"
+)
+
+// kubernetesNamespacePath is where Kubernetes mounts the service account
+// namespace inside a pod
+var kubernetesNamespacePath = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+
+// IsKubernetes reports whether the process runs in a Kubernetes pod, based
+// on the KUBERNETES_SERVICE_HOST env var injected into every container
+func IsKubernetes() bool {
+	return os.Getenv("KUBERNETES_SERVICE_HOST") != ""
+}
+
+// KubernetesNamespace returns the pod's namespace from the mounted service
+// account. It returns false if the file is absent (e.g. not in a pod, or
+// automountServiceAccountToken is disabled) or empty
+func KubernetesNamespace() (string, bool) {
+	data, err := os.ReadFile(kubernetesNamespacePath)
+	if err != nil {
+		return "", false
+	}
+	ns := strings.TrimSpace(string(data))
+	return ns, ns != ""
+}
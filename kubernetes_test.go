@@ -0,0 +1,45 @@
+package release
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestIsKubernetes(t *testing.T) {
+	t.Setenv("KUBERNETES_SERVICE_HOST", "10.0.0.1")
+	if !IsKubernetes() {
+		t.Error("IsKubernetes should be true when KUBERNETES_SERVICE_HOST is set")
+	}
+
+	t.Setenv("KUBERNETES_SERVICE_HOST", "")
+	if IsKubernetes() {
+		t.Error("IsKubernetes should be false when KUBERNETES_SERVICE_HOST is empty")
+	}
+}
+
+func TestKubernetesNamespace(t *testing.T) {
+	orig := kubernetesNamespacePath
+	defer func() { kubernetesNamespacePath = orig }()
+
+	dir := t.TempDir()
+	kubernetesNamespacePath = filepath.Join(dir, "namespace")
+
+	if _, ok := KubernetesNamespace(); ok {
+		t.Error("KubernetesNamespace should return false when the file is missing")
+	}
+
+	if err := os.WriteFile(kubernetesNamespacePath, []byte("prod\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	ns, ok := KubernetesNamespace()
+	if !ok || ns != "prod" {
+		t.Errorf("KubernetesNamespace() = %q, %v; want \"prod\", true", ns, ok)
+	}
+
+	t.Setenv("KUBERNETES_SERVICE_HOST", "10.0.0.1")
+	info := GetRuntimeInfo()
+	if !info.Kubernetes || info.KubernetesNamespace != "prod" {
+		t.Errorf("Unexpected RuntimeInfo: %+v", info)
+	}
+}
@@ -0,0 +1,36 @@
+package release
+
+// WithLabel returns a copy of the condition with the label set. The
+// original condition's labels are not modified
+func (cond Condition) WithLabel(key, value string) Condition {
+	labels := make(map[string]string, len(cond.Labels)+1)
+	for k, v := range cond.Labels {
+		labels[k] = v
+	}
+	labels[key] = value
+	cond.Labels = labels
+	return cond
+}
+
+// HasLabel reports whether the condition carries the label. An empty
+// value matches any value of the key
+func (cond Condition) HasLabel(key, value string) bool {
+	v, ok := cond.Labels[key]
+	return ok && (value == "" || v == value)
+}
+
+// FilterByLabel returns a new set containing only the conditions carrying
+// the label (see HasLabel), in their original order. Skipped conditions
+// stay skipped in the new set
+func (cs *ConditionSet) FilterByLabel(key, value string) *ConditionSet {
+	filtered := NewConditionSet()
+	for _, cond := range cs.conditions {
+		if cond.HasLabel(key, value) {
+			filtered.conditions = append(filtered.conditions, cond)
+		}
+	}
+	for name := range cs.skipped {
+		filtered.SkipChecks(name)
+	}
+	return filtered
+}
@@ -0,0 +1,51 @@
+package release
+
+import "testing"
+
+func TestFilterByLabel(t *testing.T) {
+	pass := func() (bool, error) { return true, nil }
+	base := Condition{Name: "dns", Check: pass}
+
+	cs := NewConditionSet()
+	cs.AddAll(
+		base.WithLabel("network", "").WithLabel("stage", "deploy"),
+		Condition{Name: "go-version", Check: pass, Labels: map[string]string{"stage": "build"}},
+		Condition{Name: "db", Check: pass, Labels: map[string]string{"network": "", "stage": "build"}},
+	)
+	cs.SkipChecks("db")
+
+	tests := []struct {
+		key, value string
+		want       []string
+	}{
+		{"network", "", []string{"dns", "db"}},
+		{"stage", "build", []string{"go-version", "db"}},
+		{"stage", "", []string{"dns", "go-version", "db"}},
+		{"stage", "test", nil},
+		{"missing", "", nil},
+	}
+	for _, tt := range tests {
+		results := cs.FilterByLabel(tt.key, tt.value).TestAll()
+		var got []string
+		for _, r := range results {
+			got = append(got, r.Name)
+			if r.Name == "db" && !r.Skipped {
+				t.Errorf("FilterByLabel(%q, %q) should keep db skipped", tt.key, tt.value)
+			}
+		}
+		if len(got) != len(tt.want) {
+			t.Errorf("FilterByLabel(%q, %q) = %v, want %v", tt.key, tt.value, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("FilterByLabel(%q, %q) = %v, want %v", tt.key, tt.value, got, tt.want)
+				break
+			}
+		}
+	}
+
+	if base.Labels != nil {
+		t.Error("WithLabel should not modify the original condition")
+	}
+}
@@ -0,0 +1,50 @@
+package release
+
+import (
+	"fmt"
+	"strings"
+)
+
+// IsUTF8Locale checks if the process runs under a UTF-8 locale. On Unix
+// this inspects LC_ALL, LC_CTYPE and LANG, in POSIX precedence order; on
+// Windows it checks whether the active ANSI code page is UTF-8 (65001)
+func IsUTF8Locale() bool {
+	_, utf8 := currentLocale()
+	return utf8
+}
+
+// UTF8LocaleCondition returns a condition that fails when the process
+// does not run under a UTF-8 locale, reporting the detected locale
+func UTF8LocaleCondition() Condition {
+	return newDetailedCondition("utf8-locale", "Locale uses the UTF-8 charset", func() (bool, string, error) {
+		locale, utf8 := currentLocale()
+		if !utf8 {
+			return false, fmt.Sprintf("locale %s is not UTF-8", locale), nil
+		}
+		return true, fmt.Sprintf("locale %s", locale), nil
+	})
+}
+
+// posixLocale returns the effective LC_CTYPE locale from the environment
+// and whether its charset is UTF-8. With nothing set, POSIX defaults to
+// the "C" locale
+func posixLocale(getenv func(string) string) (string, bool) {
+	for _, key := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if value := getenv(key); value != "" {
+			return fmt.Sprintf("%s=%s", key, value), isUTF8Charset(value)
+		}
+	}
+	return "C (no LC_ALL, LC_CTYPE or LANG set)", false
+}
+
+// isUTF8Charset checks the charset of a locale name like "en_US.UTF-8"
+// or "C.utf8"
+func isUTF8Charset(locale string) bool {
+	_, charset, ok := strings.Cut(locale, ".")
+	if !ok {
+		return false
+	}
+	charset, _, _ = strings.Cut(charset, "@")
+	charset = strings.ToLower(charset)
+	return charset == "utf-8" || charset == "utf8"
+}
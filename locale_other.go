@@ -0,0 +1,10 @@
+//go:build !windows
+
+package release
+
+import "os"
+
+// currentLocale reports the locale from the POSIX locale env vars
+func currentLocale() (string, bool) {
+	return posixLocale(os.Getenv)
+}
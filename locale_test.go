@@ -0,0 +1,38 @@
+package release
+
+import "testing"
+
+func TestPosixLocale(t *testing.T) {
+	tests := []struct {
+		name   string
+		env    map[string]string
+		locale string
+		utf8   bool
+	}{
+		{"LANG utf8", map[string]string{"LANG": "en_US.UTF-8"}, "LANG=en_US.UTF-8", true},
+		{"LC_ALL wins", map[string]string{"LC_ALL": "C", "LANG": "en_US.UTF-8"}, "LC_ALL=C", false},
+		{"LC_CTYPE before LANG", map[string]string{"LC_CTYPE": "C.utf8", "LANG": "POSIX"}, "LC_CTYPE=C.utf8", true},
+		{"modifier", map[string]string{"LANG": "de_DE.UTF-8@euro"}, "LANG=de_DE.UTF-8@euro", true},
+		{"latin1", map[string]string{"LANG": "en_US.ISO-8859-1"}, "LANG=en_US.ISO-8859-1", false},
+		{"unset", map[string]string{}, "C (no LC_ALL, LC_CTYPE or LANG set)", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			locale, utf8 := posixLocale(func(key string) string { return tt.env[key] })
+			if locale != tt.locale || utf8 != tt.utf8 {
+				t.Errorf("posixLocale() = %q, %v; want %q, %v", locale, utf8, tt.locale, tt.utf8)
+			}
+		})
+	}
+}
+
+func TestUTF8LocaleCondition(t *testing.T) {
+	r := UTF8LocaleCondition().evaluate()
+	if r.Detail == "" {
+		t.Error("UTF8LocaleCondition should report the detected locale")
+	}
+	if r.Passed != IsUTF8Locale() {
+		t.Errorf("Condition result %v disagrees with IsUTF8Locale", r.Passed)
+	}
+}
@@ -0,0 +1,16 @@
+package release
+
+import (
+	"fmt"
+
+	"golang.org/x/sys/windows"
+)
+
+// codePageUTF8 is the Windows code page identifier for UTF-8
+const codePageUTF8 = 65001
+
+// currentLocale reports the active ANSI code page
+func currentLocale() (string, bool) {
+	acp := windows.GetACP()
+	return fmt.Sprintf("code page %d", acp), acp == codePageUTF8
+}
@@ -0,0 +1,53 @@
+package release
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+)
+
+// LogDirWritableCondition returns a condition that fails when path is not
+// a writable directory, so a service that cannot log is caught before it
+// fails silently. Writability is verified by creating, writing and
+// removing a probe file. The detail tells a missing directory apart from
+// one the process has no permission to use
+func LogDirWritableCondition(path string) Condition {
+	return newDetailedCondition("log-dir-writable", fmt.Sprintf("Log directory %s is writable", path), func() (bool, string, error) {
+		return checkDirWritable(path)
+	})
+}
+
+// checkDirWritable verifies that path is a directory the process can
+// create files in
+func checkDirWritable(path string) (bool, string, error) {
+	info, err := os.Stat(path)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return false, fmt.Sprintf("%s does not exist", path), nil
+	case errors.Is(err, fs.ErrPermission):
+		return false, fmt.Sprintf("permission denied accessing %s", path), nil
+	case err != nil:
+		return false, "", err
+	case !info.IsDir():
+		return false, fmt.Sprintf("%s is not a directory", path), nil
+	}
+
+	f, err := os.CreateTemp(path, ".release-probe-*")
+	if errors.Is(err, fs.ErrPermission) {
+		return false, fmt.Sprintf("permission denied writing to %s", path), nil
+	}
+	if err != nil {
+		return false, fmt.Sprintf("cannot create files in %s: %v", path, err), nil
+	}
+	defer os.Remove(f.Name())
+
+	_, err = f.Write([]byte("probe\n"))
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return false, fmt.Sprintf("cannot write to %s: %v", path, err), nil
+	}
+	return true, fmt.Sprintf("%s is writable", path), nil
+}
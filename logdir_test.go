@@ -0,0 +1,54 @@
+package release
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLogDirWritableCondition(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "app.log")
+	if err := os.WriteFile(file, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		path       string
+		wantPassed bool
+		wantDetail string
+	}{
+		{"writable", dir, true, "is writable"},
+		{"missing", filepath.Join(dir, "missing"), false, "does not exist"},
+		{"file", file, false, "is not a directory"},
+	}
+	for _, tt := range tests {
+		r := LogDirWritableCondition(tt.path).evaluate()
+		if r.Error != nil || r.Passed != tt.wantPassed || !strings.Contains(r.Detail, tt.wantDetail) {
+			t.Errorf("%s: got %+v, want passed=%v with %q", tt.name, r, tt.wantPassed, tt.wantDetail)
+		}
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil || len(entries) != 1 {
+		t.Errorf("The probe file should be removed, found %v (%v)", entries, err)
+	}
+}
+
+func TestLogDirWritableConditionPermission(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "readonly")
+	if err := os.Mkdir(dir, 0o555); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chmod(dir, 0o755)
+
+	r := LogDirWritableCondition(dir).evaluate()
+	if r.Passed {
+		t.Skip("directory permissions are not enforced, e.g. running as root or on Windows")
+	}
+	if r.Error != nil || !strings.Contains(r.Detail, "permission denied writing to") {
+		t.Errorf("Expected a permission failure, got %+v", r)
+	}
+}
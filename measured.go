@@ -0,0 +1,24 @@
+package release
+
+// AddMeasured adds a condition whose check also returns the values it
+// measured, e.g. {"free_bytes": 5368709120}. They are recorded in
+// TestResult.Metrics and serialized into JSON reports, so a run doubles
+// as a snapshot of the values the gate was based on. Metrics are recorded
+// whether the check passes or fails
+func (cs *ConditionSet) AddMeasured(name, description string, check func() (bool, map[string]any, error)) {
+	cs.conditions = append(cs.conditions, newMeasuredCondition(name, description, check))
+}
+
+// newMeasuredCondition builds a condition from a measured check. Check is
+// also set, discarding the metrics, so the condition can be run directly
+func newMeasuredCondition(name, description string, check func() (bool, map[string]any, error)) Condition {
+	return Condition{
+		Name:        name,
+		Description: description,
+		Check: func() (bool, error) {
+			passed, _, err := check()
+			return passed, err
+		},
+		MeasuredCheck: check,
+	}
+}
@@ -0,0 +1,55 @@
+package release
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestAddMeasured(t *testing.T) {
+	cs := NewConditionSet()
+	cs.AddMeasured("disk", "Enough free disk", func() (bool, map[string]any, error) {
+		return false, map[string]any{"free_bytes": 1024, "mount": "/data"}, nil
+	})
+	cs.AddMeasured("broken", "Measurement fails", func() (bool, map[string]any, error) {
+		return false, nil, errors.New("statfs failed")
+	})
+
+	results := cs.TestAll()
+	disk := results[0]
+	if disk.Passed || disk.Error != nil || disk.Metrics["free_bytes"] != 1024 || disk.Metrics["mount"] != "/data" {
+		t.Errorf("disk result = %+v", disk)
+	}
+	if broken := results[1]; broken.Error == nil || broken.Metrics != nil {
+		t.Errorf("broken result = %+v", broken)
+	}
+
+	// Check is usable on its own
+	cond, _ := cs.Get("disk")
+	if ok, err := cond.Check(); ok || err != nil {
+		t.Errorf("Check() = %v, %v", ok, err)
+	}
+}
+
+func TestMeasuredResultJSON(t *testing.T) {
+	r := TestResult{Name: "disk", Passed: true, Metrics: map[string]any{"free_bytes": 1024}}
+	data, err := json.Marshal(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded TestResult
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Metrics["free_bytes"] != float64(1024) {
+		t.Errorf("decoded Metrics = %v, want free_bytes 1024", decoded.Metrics)
+	}
+
+	data, _ = json.Marshal(TestResult{Name: "plain"})
+	var fields map[string]any
+	json.Unmarshal(data, &fields)
+	if _, ok := fields["metrics"]; ok {
+		t.Errorf("metrics should be omitted when empty: %s", data)
+	}
+}
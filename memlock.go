@@ -0,0 +1,36 @@
+package release
+
+import "fmt"
+
+// CanLockMemory reports whether the process can lock all of its memory
+// (e.g. with mlockall): RLIMIT_MEMLOCK is unlimited or the process holds
+// CAP_IPC_LOCK. It returns ErrNotSupported on non-Linux platforms
+func CanLockMemory() (bool, error) {
+	soft, privileged, err := memlockLimit()
+	if err != nil {
+		return false, err
+	}
+	return privileged || soft == RLimitInfinity, nil
+}
+
+// MemlockCapableCondition returns a condition that fails when the process
+// can lock fewer than minBytes of memory. Processes holding CAP_IPC_LOCK
+// are not bound by RLIMIT_MEMLOCK and always pass
+func MemlockCapableCondition(minBytes uint64) Condition {
+	return newDetailedCondition("memlock-capable", fmt.Sprintf("Can lock at least %d bytes of memory", minBytes), func() (bool, string, error) {
+		soft, privileged, err := memlockLimit()
+		if err != nil {
+			return false, "", err
+		}
+		ok, detail := checkMemlock(soft, privileged, minBytes)
+		return ok, detail, nil
+	})
+}
+
+// checkMemlock applies the MemlockCapableCondition threshold
+func checkMemlock(soft uint64, privileged bool, minBytes uint64) (bool, string) {
+	if privileged {
+		return true, "CAP_IPC_LOCK is held, RLIMIT_MEMLOCK does not apply"
+	}
+	return soft >= minBytes, fmt.Sprintf("memlock limit is %s bytes, need %d", formatLimit(soft), minBytes)
+}
@@ -0,0 +1,17 @@
+package release
+
+import "golang.org/x/sys/unix"
+
+// memlockLimit returns the soft RLIMIT_MEMLOCK limit and whether the
+// process holds CAP_IPC_LOCK, which lets it ignore the limit
+func memlockLimit() (soft uint64, privileged bool, err error) {
+	soft, _, err = getrlimit(unix.RLIMIT_MEMLOCK)
+	if err != nil {
+		return 0, false, err
+	}
+	caps, err := effectiveCapabilities()
+	if err != nil {
+		return 0, false, err
+	}
+	return soft, caps&(1<<capabilityBits["IPC_LOCK"]) != 0, nil
+}
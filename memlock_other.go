@@ -0,0 +1,7 @@
+//go:build !linux
+
+package release
+
+func memlockLimit() (soft uint64, privileged bool, err error) {
+	return 0, false, ErrNotSupported
+}
@@ -0,0 +1,40 @@
+package release
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCanLockMemory(t *testing.T) {
+	ok, err := CanLockMemory()
+	if errors.Is(err, ErrNotSupported) {
+		t.Skip("memlock limits not supported on this platform")
+	}
+	if err != nil {
+		t.Fatalf("CanLockMemory() error = %v", err)
+	}
+	t.Logf("Can lock memory: %v", ok)
+
+	if r := MemlockCapableCondition(0).evaluate(); !r.Passed {
+		t.Errorf("MemlockCapableCondition(0) = %+v", r)
+	}
+}
+
+func TestCheckMemlock(t *testing.T) {
+	tests := []struct {
+		soft       uint64
+		privileged bool
+		min        uint64
+		want       bool
+	}{
+		{soft: 65536, min: 65536, want: true},
+		{soft: 65536, min: 1 << 20, want: false},
+		{soft: RLimitInfinity, min: 1 << 30, want: true},
+		{soft: 0, privileged: true, min: 1 << 30, want: true},
+	}
+	for _, tt := range tests {
+		if got, detail := checkMemlock(tt.soft, tt.privileged, tt.min); got != tt.want {
+			t.Errorf("checkMemlock(%d, %v, %d) = %v (%s), want %v", tt.soft, tt.privileged, tt.min, got, detail, tt.want)
+		}
+	}
+}
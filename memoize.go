@@ -0,0 +1,109 @@
+package release
+
+import (
+	"context"
+	"sync"
+)
+
+// memoRun is the memo table of one test run. Each runner (TestAll,
+// TestOnly, TestMatching, TestAllChan, TestAllRateLimited, TestAllTree,
+// TestAllParallel, TestAllParallelRateLimited) creates its own and passes
+// it to the checks in the run's context, so concurrent runs never share
+// or invalidate each other's results. Groups (see AddGroup) run within
+// their parent's run
+type memoRun struct {
+	mu      sync.Mutex
+	entries map[string]*memoEntry
+}
+
+// memoEntry holds the cached outcome of a memoized check for one run
+type memoEntry struct {
+	once     sync.Once
+	ok       bool
+	err      error
+	panicked any
+}
+
+// memoRunKey is the context key of the run's memo table
+type memoRunKey struct{}
+
+// beginRun starts a new test run for Memoize and returns its context
+func beginRun() context.Context {
+	return context.WithValue(context.Background(), memoRunKey{}, &memoRun{entries: make(map[string]*memoEntry)})
+}
+
+// entry returns the cache entry for key in r, creating it on first use
+func (r *memoRun) entry(key string) *memoEntry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	e, ok := r.entries[key]
+	if !ok {
+		e = &memoEntry{}
+		r.entries[key] = e
+	}
+	return e
+}
+
+// Memoize wraps check so that it runs at most once per test run for the
+// given key. Every wrapped check sharing a key returns the first result,
+// which lets several conditions share one expensive probe.
+//
+// The run is identified by the context the wrapped function is called
+// with: add conditions with AddContext and pass their context on,
+// including to goroutines they start. Every runner of a ConditionSet,
+// such as TestAll or TestOnly, gets its own cache, so results never leak
+// between runs, including runs that overlap. Calls with a context that
+// does not come from a run, such as context.Background(), are not cached.
+// The returned function is safe for concurrent use; concurrent callers in
+// a run wait for the single in-flight check. A panic in check is re-raised
+// for every caller in the run
+func Memoize(key string, check func(ctx context.Context) (bool, error)) func(ctx context.Context) (bool, error) {
+	return func(ctx context.Context) (bool, error) {
+		r, _ := ctx.Value(memoRunKey{}).(*memoRun)
+		if r == nil {
+			return check(ctx)
+		}
+		e := r.entry(key)
+		e.once.Do(func() {
+			defer func() {
+				if v := recover(); v != nil {
+					e.panicked = v
+				}
+			}()
+			e.ok, e.err = check(ctx)
+		})
+		if e.panicked != nil {
+			panic(e.panicked)
+		}
+		return e.ok, e.err
+	}
+}
+
+// AddOnce adds a condition whose check runs on the first evaluation only;
+// every later run reuses that result, including an error or a panic, for
+// the lifetime of the process. Use it for facts that cannot change while
+// the process runs, such as the binary's checksum or the build
+// configuration. Use Memoize to share a probe within a single run, and
+// AddBackground for results that can change, such as reachability
+func (cs *ConditionSet) AddOnce(name, description string, check func() (bool, error)) {
+	var (
+		once     sync.Once
+		ok       bool
+		err      error
+		panicked any
+	)
+	cs.Add(name, description, func() (bool, error) {
+		once.Do(func() {
+			defer func() {
+				if v := recover(); v != nil {
+					panicked = v
+				}
+			}()
+			ok, err = check()
+		})
+		if panicked != nil {
+			panic(panicked)
+		}
+		return ok, err
+	})
+}
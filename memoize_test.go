@@ -0,0 +1,208 @@
+package release
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestMemoize(t *testing.T) {
+	var calls atomic.Int32
+	probe := Memoize("test-memoize-probe", func(context.Context) (bool, error) {
+		calls.Add(1)
+		return true, nil
+	})
+
+	cs := NewConditionSet()
+	cs.AddContext("a", "Shares the probe", probe)
+	cs.AddContext("b", "Shares the probe", probe)
+	cs.AddContext("c", "Shares the probe", Memoize("test-memoize-probe", func(context.Context) (bool, error) {
+		t.Error("A second check with the same key should not run")
+		return false, nil
+	}))
+
+	if !cs.TestAll().AllPassed() {
+		t.Error("Expected all memoized conditions to pass")
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("Probe ran %d times in one run, want 1", got)
+	}
+
+	// A new run gets a new cache
+	cs.TestOnly("a")
+	if got := calls.Load(); got != 2 {
+		t.Errorf("Probe ran %d times after two runs, want 2", got)
+	}
+}
+
+func TestMemoizeErrorAndPanic(t *testing.T) {
+	errProbe := errors.New("unreachable")
+	cs := NewConditionSet()
+	cs.AddContext("err", "Memoized error", Memoize("test-memoize-err", func(context.Context) (bool, error) {
+		return false, errProbe
+	}))
+	cs.AddContext("panic", "Memoized panic", Memoize("test-memoize-panic", func(context.Context) (bool, error) {
+		panic("boom")
+	}))
+	cs.AddContext("panic-again", "Memoized panic", Memoize("test-memoize-panic", func(context.Context) (bool, error) {
+		return true, nil
+	}))
+
+	results := cs.TestAll()
+	if !errors.Is(results[0].Error, errProbe) {
+		t.Errorf("Memoized error = %v, want %v", results[0].Error, errProbe)
+	}
+	for _, r := range results[1:] {
+		var pe *PanicError
+		if !errors.As(r.Error, &pe) {
+			t.Errorf("%s: expected a PanicError, got %v", r.Name, r.Error)
+		}
+	}
+}
+
+func TestMemoizeConcurrent(t *testing.T) {
+	ctx := beginRun()
+	var calls atomic.Int32
+	check := Memoize("test-memoize-concurrent", func(context.Context) (bool, error) {
+		calls.Add(1)
+		return true, nil
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if ok, err := check(ctx); !ok || err != nil {
+				t.Errorf("check() = %v, %v", ok, err)
+			}
+		}()
+	}
+	wg.Wait()
+	if got := calls.Load(); got != 1 {
+		t.Errorf("Concurrent probe ran %d times, want 1", got)
+	}
+}
+
+func TestMemoizeFromGoroutine(t *testing.T) {
+	var calls atomic.Int32
+	probe := Memoize("test-memoize-goroutine", func(context.Context) (bool, error) {
+		calls.Add(1)
+		return true, nil
+	})
+
+	cs := NewConditionSet()
+	cs.AddContext("direct", "Calls the probe", probe)
+	cs.AddContext("fan-out", "Calls the probe from goroutines", func(ctx context.Context) (bool, error) {
+		var wg sync.WaitGroup
+		var failed atomic.Bool
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if ok, err := probe(ctx); !ok || err != nil {
+					failed.Store(true)
+				}
+			}()
+		}
+		wg.Wait()
+		return !failed.Load(), nil
+	})
+
+	if !cs.TestAll().AllPassed() {
+		t.Error("Expected all memoized conditions to pass")
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("Probe ran %d times in one run with goroutines, want 1", got)
+	}
+}
+
+func TestMemoizeOverlappingRuns(t *testing.T) {
+	// The probe passes the first time it runs only, so a run reusing
+	// another run's result, or re-running its own probe, is visible
+	var calls atomic.Int32
+	probe := Memoize("test-memoize-overlap", func(context.Context) (bool, error) {
+		return calls.Add(1) == 1, nil
+	})
+
+	started, otherDone := make(chan struct{}), make(chan struct{})
+	first := NewConditionSet()
+	first.AddContext("before", "Probe before the other run", probe)
+	first.AddContext("during", "Probe while the other run starts", func(ctx context.Context) (bool, error) {
+		close(started)
+		<-otherDone
+		return probe(ctx)
+	})
+
+	done := make(chan TestResults)
+	go func() { done <- first.TestAll() }()
+
+	<-started
+	second := NewConditionSet()
+	second.AddContext("other", "Probe in the other run", probe)
+	if r := second.TestAll()[0]; r.Passed {
+		t.Errorf("The other run reused the first run's result: %+v", r)
+	}
+	close(otherDone)
+
+	for _, r := range <-done {
+		if !r.Passed {
+			t.Errorf("%s: the other run invalidated the first run's result", r.Name)
+		}
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("Probe ran %d times in two runs, want 2", got)
+	}
+}
+
+func TestMemoizeOutsideRun(t *testing.T) {
+	calls := 0
+	probe := Memoize("test-memoize-outside", func(context.Context) (bool, error) {
+		calls++
+		return true, nil
+	})
+	probe(context.Background())
+	probe(context.Background())
+	if calls != 2 {
+		t.Errorf("Probe ran %d times outside a run, want 2 (uncached)", calls)
+	}
+}
+
+func TestAddOnce(t *testing.T) {
+	calls := 0
+	cs := NewConditionSet()
+	cs.AddOnce("checksum", "Binary checksum matches", func() (bool, error) {
+		calls++
+		return calls == 1, nil
+	})
+
+	for i := 0; i < 3; i++ {
+		if r := cs.TestAll()[0]; !r.Passed {
+			t.Errorf("Run %d should reuse the first result: %+v", i, r)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("Check ran %d times, want 1", calls)
+	}
+}
+
+func TestAddOncePanic(t *testing.T) {
+	calls := 0
+	cs := NewConditionSet()
+	cs.AddOnce("broken", "Panics", func() (bool, error) {
+		calls++
+		panic("boom")
+	})
+
+	for i := 0; i < 2; i++ {
+		var pe *PanicError
+		if r := cs.TestAll()[0]; !errors.As(r.Error, &pe) {
+			t.Errorf("Run %d should report the panic, got %+v", i, r)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("Check ran %d times, want 1", calls)
+	}
+}
@@ -0,0 +1,36 @@
+package release
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+// IsModuleBuild reports whether the binary was built in module mode, i.e.
+// its build info names a main module. GOPATH-mode builds and binaries
+// without build info are not module builds
+func IsModuleBuild() bool {
+	ok, _ := checkModuleBuild(debug.ReadBuildInfo())
+	return ok
+}
+
+// ModuleBuildCondition returns a condition that fails when the binary was
+// not built in module mode, so its dependencies cannot be traced. The
+// detail tells a GOPATH-mode build apart from a binary with no build info
+// at all, e.g. a very old or stripped build
+func ModuleBuildCondition() Condition {
+	return newDetailedCondition("module-build", "Built in module mode", func() (bool, string, error) {
+		ok, detail := checkModuleBuild(debug.ReadBuildInfo())
+		return ok, detail, nil
+	})
+}
+
+// checkModuleBuild evaluates the result of debug.ReadBuildInfo
+func checkModuleBuild(info *debug.BuildInfo, ok bool) (bool, string) {
+	switch {
+	case !ok:
+		return false, "binary has no build info"
+	case info.Main.Path == "":
+		return false, "no main module recorded, built in GOPATH mode"
+	}
+	return true, fmt.Sprintf("built as module %s", info.Main.Path)
+}
@@ -0,0 +1,36 @@
+package release
+
+import (
+	"runtime/debug"
+	"testing"
+)
+
+func TestCheckModuleBuild(t *testing.T) {
+	tests := []struct {
+		name   string
+		info   *debug.BuildInfo
+		ok     bool
+		want   bool
+		detail string
+	}{
+		{"module", &debug.BuildInfo{Main: debug.Module{Path: "example.com/app"}}, true, true, "built as module example.com/app"},
+		{"gopath", &debug.BuildInfo{Path: "app"}, true, false, "no main module recorded, built in GOPATH mode"},
+		{"no build info", nil, false, false, "binary has no build info"},
+	}
+	for _, tt := range tests {
+		got, detail := checkModuleBuild(tt.info, tt.ok)
+		if got != tt.want || detail != tt.detail {
+			t.Errorf("%s: checkModuleBuild() = %v, %q; want %v, %q", tt.name, got, detail, tt.want, tt.detail)
+		}
+	}
+}
+
+func TestModuleBuildCondition(t *testing.T) {
+	// Test binaries are built in module mode
+	if !IsModuleBuild() {
+		t.Error("IsModuleBuild() = false for a module-mode test binary")
+	}
+	if r := ModuleBuildCondition().evaluate(); !r.Passed {
+		t.Errorf("ModuleBuildCondition() = %+v", r)
+	}
+}
@@ -0,0 +1,78 @@
+package release
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+// HostArch returns the native architecture of the host CPU as a GOARCH
+// value, looking through emulation layers such as Rosetta 2 on macOS and
+// x64 emulation on Windows on ARM. On Linux it reports the kernel's
+// machine type, which qemu-user emulates along with the rest of the
+// system call interface, so emulation through binfmt_misc is not detected
+// there. It returns ErrNotSupported on other platforms
+func HostArch() (string, error) {
+	return hostArch()
+}
+
+// NativeExecutionCondition returns a condition that passes only when the
+// binary's architecture (GOARCH) matches the host's architecture as
+// reported by HostArch: on macOS and Windows, the process is not running
+// under emulation; on Linux, it catches a binary for a narrower
+// architecture running in compatibility mode, such as 386 on an x86_64
+// kernel, but not qemu-user emulation
+func NativeExecutionCondition() Condition {
+	return newDetailedCondition("native-execution", "Binary architecture matches the host CPU", func() (bool, string, error) {
+		host, err := HostArch()
+		if err != nil {
+			return false, "", err
+		}
+		return archMatches(host, runtime.GOARCH), fmt.Sprintf("host arch %s, binary arch %s", host, runtime.GOARCH), nil
+	})
+}
+
+// unameArchs maps uname machine names to GOARCH values
+var unameArchs = map[string]string{
+	"x86_64":      "amd64",
+	"amd64":       "amd64",
+	"i386":        "386",
+	"i486":        "386",
+	"i586":        "386",
+	"i686":        "386",
+	"aarch64":     "arm64",
+	"arm64":       "arm64",
+	"ppc64le":     "ppc64le",
+	"ppc64":       "ppc64",
+	"s390x":       "s390x",
+	"riscv64":     "riscv64",
+	"loongarch64": "loong64",
+	"mips":        "mips",
+	"mips64":      "mips64",
+}
+
+// archFromMachine converts a uname machine name to a GOARCH value. 32-bit
+// ARM variants (armv6l, armv7l, ...) map to arm. Unknown names are
+// returned unchanged
+func archFromMachine(machine string) string {
+	if arch, ok := unameArchs[machine]; ok {
+		return arch
+	}
+	if strings.HasPrefix(machine, "armv") {
+		return "arm"
+	}
+	return machine
+}
+
+// archMatches reports whether a binary built for goarch runs natively on
+// a host of the given arch. uname does not report MIPS endianness, so
+// mips and mips64 hosts match both byte orders
+func archMatches(host, goarch string) bool {
+	switch host {
+	case "mips":
+		return goarch == "mips" || goarch == "mipsle"
+	case "mips64":
+		return goarch == "mips64" || goarch == "mips64le"
+	}
+	return host == goarch
+}
@@ -0,0 +1,25 @@
+package release
+
+import (
+	"errors"
+
+	"golang.org/x/sys/unix"
+)
+
+func hostArch() (string, error) {
+	// Under Rosetta 2 uname reports x86_64, so ask whether the process is
+	// translated first. The sysctl does not exist on Intel Macs
+	translated, err := unix.SysctlUint32("sysctl.proc_translated")
+	if err != nil && !errors.Is(err, unix.ENOENT) {
+		return "", err
+	}
+	if translated == 1 {
+		return "arm64", nil
+	}
+
+	var u unix.Utsname
+	if err := unix.Uname(&u); err != nil {
+		return "", err
+	}
+	return archFromMachine(unix.ByteSliceToString(u.Machine[:])), nil
+}
@@ -0,0 +1,14 @@
+package release
+
+import "golang.org/x/sys/unix"
+
+// hostArch reports the kernel's machine type. Under qemu-user, uname and
+// the auxiliary vector (AT_PLATFORM, AT_HWCAP) describe the emulated CPU,
+// so the emulation is invisible here
+func hostArch() (string, error) {
+	var u unix.Utsname
+	if err := unix.Uname(&u); err != nil {
+		return "", err
+	}
+	return archFromMachine(unix.ByteSliceToString(u.Machine[:])), nil
+}
@@ -0,0 +1,7 @@
+//go:build !linux && !darwin && !windows
+
+package release
+
+func hostArch() (string, error) {
+	return "", ErrNotSupported
+}
@@ -0,0 +1,59 @@
+package release
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestArchFromMachine(t *testing.T) {
+	tests := map[string]string{
+		"x86_64":      "amd64",
+		"i686":        "386",
+		"aarch64":     "arm64",
+		"arm64":       "arm64",
+		"armv7l":      "arm",
+		"armv6l":      "arm",
+		"armv8l":      "arm",
+		"loongarch64": "loong64",
+		"sparc64":     "sparc64",
+	}
+	for machine, want := range tests {
+		if got := archFromMachine(machine); got != want {
+			t.Errorf("archFromMachine(%q) = %q, want %q", machine, got, want)
+		}
+	}
+}
+
+func TestArchMatches(t *testing.T) {
+	tests := []struct {
+		host, goarch string
+		want         bool
+	}{
+		{"amd64", "amd64", true},
+		{"arm64", "amd64", false},
+		{"amd64", "386", false},
+		{"mips", "mipsle", true},
+		{"mips64", "mips64", true},
+		{"mips64", "mips", false},
+	}
+	for _, tt := range tests {
+		if got := archMatches(tt.host, tt.goarch); got != tt.want {
+			t.Errorf("archMatches(%q, %q) = %v, want %v", tt.host, tt.goarch, got, tt.want)
+		}
+	}
+}
+
+func TestNativeExecutionCondition(t *testing.T) {
+	r := NativeExecutionCondition().evaluate()
+	if errors.Is(r.Error, ErrNotSupported) {
+		t.Skip("host arch detection not supported on this platform")
+	}
+	if r.Error != nil {
+		t.Fatalf("NativeExecutionCondition() error = %v", r.Error)
+	}
+	if !strings.Contains(r.Detail, "host arch") {
+		t.Errorf("Unexpected detail: %q", r.Detail)
+	}
+	t.Logf("Native execution: %v (%s)", r.Passed, r.Detail)
+}
@@ -0,0 +1,26 @@
+package release
+
+import (
+	"debug/pe"
+	"fmt"
+
+	"golang.org/x/sys/windows"
+)
+
+func hostArch() (string, error) {
+	var processMachine, nativeMachine uint16
+	if err := windows.IsWow64Process2(windows.CurrentProcess(), &processMachine, &nativeMachine); err != nil {
+		return "", err
+	}
+	switch nativeMachine {
+	case pe.IMAGE_FILE_MACHINE_AMD64:
+		return "amd64", nil
+	case pe.IMAGE_FILE_MACHINE_I386:
+		return "386", nil
+	case pe.IMAGE_FILE_MACHINE_ARM64:
+		return "arm64", nil
+	case pe.IMAGE_FILE_MACHINE_ARMNT:
+		return "arm", nil
+	}
+	return "", fmt.Errorf("unknown native machine type %#x", nativeMachine)
+}
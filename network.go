@@ -0,0 +1,89 @@
+package release
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+// TCPReachableCondition returns a condition that passes when a TCP
+// connection to addr ("host:port") can be established within timeout
+func TCPReachableCondition(addr string, timeout time.Duration) Condition {
+	return newDetailedCondition("tcp-reachable-"+addr, fmt.Sprintf("%s is reachable over TCP", addr), func() (bool, string, error) {
+		conn, err := net.DialTimeout("tcp", addr, timeout)
+		if err != nil {
+			return false, err.Error(), nil
+		}
+		conn.Close()
+		return true, fmt.Sprintf("connected to %s", addr), nil
+	})
+}
+
+// AnyReachableCondition returns a condition that passes when at least one
+// of addrs is reachable over TCP, modeling redundant backends. Every
+// address is probed and the detail reports the ones that failed
+func AnyReachableCondition(addrs []string, timeout time.Duration) Condition {
+	conditions := make([]Condition, 0, len(addrs))
+	for _, addr := range addrs {
+		cond := TCPReachableCondition(addr, timeout)
+		cond.Name = addr
+		conditions = append(conditions, cond)
+	}
+
+	cond := AtLeast(1, conditions...)
+	cond.Name = "any-reachable"
+	cond.Description = fmt.Sprintf("At least one of %d backends is reachable", len(addrs))
+	return cond
+}
+
+// UsableInterfaces returns the network interfaces that are up, are not
+// loopback interfaces and have at least one address assigned
+func UsableInterfaces() ([]net.Interface, error) {
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return nil, err
+	}
+	return usableInterfaces(ifaces, func(iface net.Interface) ([]net.Addr, error) {
+		return iface.Addrs()
+	})
+}
+
+// MinNetworkInterfacesCondition returns a condition that fails when fewer
+// than n usable interfaces (see UsableInterfaces) are present, e.g. in a
+// container started before its networking was configured
+func MinNetworkInterfacesCondition(n int) Condition {
+	return newDetailedCondition("min-network-interfaces", fmt.Sprintf("At least %d usable network interfaces", n), func() (bool, string, error) {
+		ifaces, err := UsableInterfaces()
+		if err != nil {
+			return false, "", err
+		}
+		names := make([]string, len(ifaces))
+		for i, iface := range ifaces {
+			names[i] = iface.Name
+		}
+		detail := fmt.Sprintf("found %d usable interface(s), need %d", len(ifaces), n)
+		if len(names) > 0 {
+			detail += ": " + strings.Join(names, ", ")
+		}
+		return len(ifaces) >= n, detail, nil
+	})
+}
+
+// usableInterfaces filters ifaces, looking up addresses with addrs
+func usableInterfaces(ifaces []net.Interface, addrs func(net.Interface) ([]net.Addr, error)) ([]net.Interface, error) {
+	var usable []net.Interface
+	for _, iface := range ifaces {
+		if iface.Flags&net.FlagUp == 0 || iface.Flags&net.FlagLoopback != 0 {
+			continue
+		}
+		assigned, err := addrs(iface)
+		if err != nil {
+			return nil, fmt.Errorf("interface %s: %w", iface.Name, err)
+		}
+		if len(assigned) > 0 {
+			usable = append(usable, iface)
+		}
+	}
+	return usable, nil
+}
@@ -0,0 +1,93 @@
+package release
+
+import (
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+// listenLocal starts a TCP listener on a random local port
+func listenLocal(t *testing.T) net.Listener {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("cannot listen on loopback: %v", err)
+	}
+	t.Cleanup(func() { ln.Close() })
+	return ln
+}
+
+// closedAddr returns a local address with nothing listening on it
+func closedAddr(t *testing.T) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("cannot listen on loopback: %v", err)
+	}
+	addr := ln.Addr().String()
+	ln.Close()
+	return addr
+}
+
+func TestTCPReachableCondition(t *testing.T) {
+	ln := listenLocal(t)
+
+	if ok, err := TCPReachableCondition(ln.Addr().String(), time.Second).Check(); !ok || err != nil {
+		t.Errorf("Listening address: got %v, %v; want true, nil", ok, err)
+	}
+
+	result := TCPReachableCondition(closedAddr(t), time.Second).evaluate()
+	if result.Passed || result.Detail == "" {
+		t.Errorf("Closed address: got %+v; want a failure with detail", result)
+	}
+}
+
+func TestAnyReachableCondition(t *testing.T) {
+	ln := listenLocal(t)
+	down := closedAddr(t)
+
+	result := AnyReachableCondition([]string{down, ln.Addr().String()}, time.Second).evaluate()
+	if !result.Passed {
+		t.Errorf("One reachable backend should pass: %+v", result)
+	}
+	if !strings.Contains(result.Detail, down) {
+		t.Errorf("Detail should name the unreachable backend %s: %q", down, result.Detail)
+	}
+
+	if result := AnyReachableCondition([]string{down}, time.Second).evaluate(); result.Passed {
+		t.Error("No reachable backend should fail")
+	}
+}
+
+func TestUsableInterfaces(t *testing.T) {
+	ifaces := []net.Interface{
+		{Name: "lo", Flags: net.FlagUp | net.FlagLoopback},
+		{Name: "eth0", Flags: net.FlagUp | net.FlagBroadcast},
+		{Name: "eth1", Flags: net.FlagBroadcast},
+		{Name: "eth2", Flags: net.FlagUp},
+	}
+	addrs := func(iface net.Interface) ([]net.Addr, error) {
+		if iface.Name == "eth2" {
+			return nil, nil
+		}
+		return []net.Addr{&net.IPNet{IP: net.IPv4(10, 0, 0, 2), Mask: net.CIDRMask(24, 32)}}, nil
+	}
+
+	usable, err := usableInterfaces(ifaces, addrs)
+	if err != nil {
+		t.Fatalf("usableInterfaces() error = %v", err)
+	}
+	if len(usable) != 1 || usable[0].Name != "eth0" {
+		t.Errorf("usableInterfaces() = %v, want [eth0]", usable)
+	}
+
+	found, err := UsableInterfaces()
+	if err != nil {
+		t.Skipf("cannot list interfaces: %v", err)
+	}
+	r := MinNetworkInterfacesCondition(len(found) + 1).evaluate()
+	if r.Passed || !strings.Contains(r.Detail, "usable interface(s)") {
+		t.Errorf("MinNetworkInterfacesCondition(%d) = %+v", len(found)+1, r)
+	}
+}
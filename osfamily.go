@@ -0,0 +1,40 @@
+package release
+
+import "runtime"
+
+// OS families returned by OSFamily
+const (
+	FamilyUnix    = "unix"
+	FamilyWindows = "windows"
+	FamilyWasm    = "wasm"
+	FamilyPlan9   = "plan9"
+)
+
+// OSFamily returns the family of the current OS: "unix", "windows",
+// "wasm" or "plan9"
+func OSFamily() string {
+	return osFamily(runtime.GOOS)
+}
+
+// IsUnix checks if the current OS is Unix-like, matching the same set of
+// GOOS values as the "unix" build constraint
+func IsUnix() bool {
+	return OSFamily() == FamilyUnix
+}
+
+// osFamily maps a GOOS value to its family. Unknown values return ""
+func osFamily(goos string) string {
+	switch goos {
+	case "aix", "android", "darwin", "dragonfly", "freebsd", "hurd", "illumos",
+		"ios", "linux", "netbsd", "openbsd", "solaris":
+		return FamilyUnix
+	case "windows":
+		return FamilyWindows
+	case "js", "wasip1":
+		return FamilyWasm
+	case "plan9":
+		return FamilyPlan9
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,39 @@
+package release
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestOSFamily(t *testing.T) {
+	tests := []struct {
+		goos     string
+		expected string
+	}{
+		{"linux", FamilyUnix},
+		{"darwin", FamilyUnix},
+		{"freebsd", FamilyUnix},
+		{"openbsd", FamilyUnix},
+		{"illumos", FamilyUnix},
+		{"windows", FamilyWindows},
+		{"js", FamilyWasm},
+		{"wasip1", FamilyWasm},
+		{"plan9", FamilyPlan9},
+		{"fakeos", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.goos, func(t *testing.T) {
+			if result := osFamily(tt.goos); result != tt.expected {
+				t.Errorf("osFamily(%s) = %q, want %q", tt.goos, result, tt.expected)
+			}
+		})
+	}
+
+	if IsUnix() != (osFamily(runtime.GOOS) == FamilyUnix) {
+		t.Error("IsUnix disagrees with OSFamily")
+	}
+	if GetBuildInfo().OSFamily != OSFamily() {
+		t.Error("BuildInfo.OSFamily should match OSFamily()")
+	}
+}
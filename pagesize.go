@@ -0,0 +1,27 @@
+package release
+
+import (
+	"fmt"
+	"os"
+)
+
+// SystemPageSize returns the memory page size in bytes, e.g. 4096 on most
+// amd64 systems but 16384 on Apple Silicon and 65536 on some arm64 and
+// ppc64 Linux kernels
+func SystemPageSize() int {
+	return os.Getpagesize()
+}
+
+// PageSizeCondition returns a condition that fails when the page size
+// differs from the one the binary expects, e.g. for memory-mapping code
+// that assumes 4K pages
+func PageSizeCondition(expected int) Condition {
+	return newDetailedCondition("page-size", fmt.Sprintf("Page size is %d bytes", expected), func() (bool, string, error) {
+		return checkPageSize(SystemPageSize(), expected)
+	})
+}
+
+// checkPageSize compares the actual page size with the expected one
+func checkPageSize(actual, expected int) (bool, string, error) {
+	return actual == expected, fmt.Sprintf("page size is %d bytes, expected %d", actual, expected), nil
+}
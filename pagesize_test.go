@@ -0,0 +1,31 @@
+package release
+
+import (
+	"os"
+	"testing"
+)
+
+func TestSystemPageSize(t *testing.T) {
+	size := SystemPageSize()
+	if size <= 0 || size&(size-1) != 0 {
+		t.Errorf("SystemPageSize() = %d, want a positive power of two", size)
+	}
+	if size != os.Getpagesize() {
+		t.Errorf("SystemPageSize() = %d, want %d", size, os.Getpagesize())
+	}
+}
+
+func TestPageSizeCondition(t *testing.T) {
+	size := SystemPageSize()
+	if r := PageSizeCondition(size).evaluate(); !r.Passed {
+		t.Errorf("PageSizeCondition(%d) should pass: %+v", size, r)
+	}
+
+	r := PageSizeCondition(size * 2).evaluate()
+	if r.Passed || r.Error != nil {
+		t.Errorf("PageSizeCondition(%d) should fail cleanly: %+v", size*2, r)
+	}
+	if _, want, _ := checkPageSize(size, size*2); r.Detail != want {
+		t.Errorf("Detail = %q, want %q", r.Detail, want)
+	}
+}
@@ -0,0 +1,51 @@
+package release
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+// maxPanicFrames bounds the stack captured for a panicking check
+const maxPanicFrames = 8
+
+// PanicError is recorded as the error of a check that panicked
+type PanicError struct {
+	// Value is the value passed to panic
+	Value any
+	// Stack is a short stack trace of the panicking goroutine
+	Stack string
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("check panicked: %v\n%s", e.Value, e.Stack)
+}
+
+// Unwrap returns the panic value if it is an error
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// newPanicError captures a short stack for a recovered panic value. It
+// must be called from the deferred function that recovered
+func newPanicError(value any) *PanicError {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(3, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	var b strings.Builder
+	count := 0
+	for count < maxPanicFrames {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, "runtime.") {
+			fmt.Fprintf(&b, "%s\n\t%s:%d\n", frame.Function, frame.File, frame.Line)
+			count++
+		}
+		if !more {
+			break
+		}
+	}
+
+	return &PanicError{Value: value, Stack: b.String()}
+}
@@ -0,0 +1,49 @@
+package release
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestTestAllRecoversPanics(t *testing.T) {
+	cs := NewConditionSet()
+	cs.Add("panics", "Buggy check", func() (bool, error) {
+		panic("something broke")
+	})
+	cs.Add("after", "Runs after the panic", func() (bool, error) {
+		return true, nil
+	})
+
+	results := cs.TestAll()
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 results, got %d", len(results))
+	}
+
+	var panicErr *PanicError
+	if results[0].Passed || !errors.As(results[0].Error, &panicErr) {
+		t.Fatalf("Expected a failed result with a PanicError, got %+v", results[0])
+	}
+	if panicErr.Value != "something broke" {
+		t.Errorf("Panic value = %v, want %q", panicErr.Value, "something broke")
+	}
+	if !strings.Contains(panicErr.Stack, "TestTestAllRecoversPanics") {
+		t.Errorf("Stack should include the panicking function:\n%s", panicErr.Stack)
+	}
+	if !results[1].Passed {
+		t.Error("Conditions after a panic should still run")
+	}
+}
+
+func TestPanicErrorUnwrap(t *testing.T) {
+	cs := NewConditionSet()
+	cs.AddDetailed("panics", "Panics with an error", func() (bool, string, error) {
+		panic(io.ErrUnexpectedEOF)
+	})
+
+	result := <-cs.TestAllChan()
+	if !errors.Is(result.Error, io.ErrUnexpectedEOF) {
+		t.Errorf("Expected the panic error to be wrapped, got %v", result.Error)
+	}
+}
@@ -0,0 +1,52 @@
+package release
+
+import (
+	"runtime"
+	"sync"
+
+	"golang.org/x/time/rate"
+)
+
+// TestAllParallel tests all conditions like TestAll, but runs up to
+// maxConcurrency checks at once, each in its own goroutine, so slow
+// network probes overlap. A maxConcurrency of zero or less means
+// runtime.NumCPU(). Results are returned in the order the conditions were
+// added, and a panicking check is recorded as a failed result with a
+// PanicError like in every other runner. Checks must be safe to run
+// concurrently with each other; probes shared with Memoize still run once.
+// Use TestAllParallelRateLimited to also limit how fast checks start
+func (cs *ConditionSet) TestAllParallel(maxConcurrency int) TestResults {
+	return cs.testAllParallel(maxConcurrency, newRunLimiter(0))
+}
+
+// testAllParallel runs the checks concurrently, taking a token from
+// limiter before starting each one that is not skipped
+func (cs *ConditionSet) testAllParallel(maxConcurrency int, limiter *rate.Limiter) TestResults {
+	if maxConcurrency <= 0 {
+		maxConcurrency = runtime.NumCPU()
+	}
+
+	results := make(TestResults, len(cs.conditions))
+	skips := cs.skipReasons()
+	ctx := beginRun()
+
+	sem := make(chan struct{}, maxConcurrency)
+	var wg sync.WaitGroup
+	for i, cond := range cs.conditions {
+		wg.Add(1)
+		sem <- struct{}{}
+		if _, skipped := skips[cond.Name]; !skipped {
+			waitForToken(limiter)
+		}
+		go func(i int, cond Condition) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			results[i] = evaluateUnlessSkipped(ctx, cond, skips)
+		}(i, cond)
+	}
+	wg.Wait()
+
+	return results
+}
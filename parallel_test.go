@@ -0,0 +1,75 @@
+package release
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestAllParallel(t *testing.T) {
+	var running, peak atomic.Int32
+	probe := func(ok bool) func() (bool, error) {
+		return func() (bool, error) {
+			n := running.Add(1)
+			defer running.Add(-1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			return ok, nil
+		}
+	}
+
+	cs := NewConditionSet()
+	cs.Add("a", "", probe(true))
+	cs.Add("b", "", probe(false))
+	cs.Add("panics", "", func() (bool, error) { panic("boom") })
+	cs.Add("c", "", probe(true))
+	cs.Add("skipped", "", func() (bool, error) { return false, errors.New("should not run") })
+	cs.Add("d", "", probe(true))
+	cs.SkipChecks("skipped")
+
+	results := cs.TestAllParallel(2)
+
+	want := []string{"a", "b", "panics", "c", "skipped", "d"}
+	if len(results) != len(want) {
+		t.Fatalf("TestAllParallel() returned %d results, want %d", len(results), len(want))
+	}
+	for i, name := range want {
+		if results[i].Name != name {
+			t.Errorf("results[%d].Name = %q, want %q", i, results[i].Name, name)
+		}
+	}
+	if !results[0].Passed || results[1].Passed {
+		t.Errorf("unexpected outcomes: %+v, %+v", results[0], results[1])
+	}
+	var panicErr *PanicError
+	if !errors.As(results[2].Error, &panicErr) || results[2].Passed {
+		t.Errorf("panicking check result = %+v, want a PanicError", results[2])
+	}
+	if !results[4].Skipped {
+		t.Errorf("skipped result = %+v", results[4])
+	}
+	if p := peak.Load(); p > 2 {
+		t.Errorf("peak concurrency = %d, want at most 2", p)
+	}
+}
+
+func TestAllParallelDefaultConcurrency(t *testing.T) {
+	cs := NewConditionSet()
+	for _, name := range []string{"a", "b", "c"} {
+		cs.Add(name, "", func() (bool, error) { return true, nil })
+	}
+	for _, n := range []int{0, -1, 10} {
+		if results := cs.TestAllParallel(n); len(results) != 3 || !results.AllPassed() {
+			t.Errorf("TestAllParallel(%d) = %+v", n, results)
+		}
+	}
+	if results := NewConditionSet().TestAllParallel(4); len(results) != 0 {
+		t.Errorf("TestAllParallel() on an empty set = %+v", results)
+	}
+}
@@ -0,0 +1,118 @@
+package release
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// cgroupRoot is where the cgroup filesystem is mounted
+var cgroupRoot = "/sys/fs/cgroup"
+
+// procSelfCgroupPath lists the cgroups of the process
+var procSelfCgroupPath = "/proc/self/cgroup"
+
+// PidsLimit returns the pids.max limit and the pids.current usage of the
+// process's cgroup, for cgroup v1 and v2. An unlimited pids.max is
+// reported as RLimitInfinity. It returns ErrNotSupported on non-Linux
+// platforms and when the process is not in a cgroup with the pids
+// controller, e.g. outside a container
+func PidsLimit() (limit, current uint64, err error) {
+	return pidsLimit()
+}
+
+// PidHeadroomCondition returns a condition that fails when fewer than
+// minFree PIDs are left below the cgroup's pids.max. Every OS thread
+// counts against the limit, so goroutine-heavy services blocked in
+// syscalls can exhaust it under load
+func PidHeadroomCondition(minFree uint64) Condition {
+	return newDetailedCondition("pid-headroom", fmt.Sprintf("At least %d PIDs free in the cgroup", minFree), func() (bool, string, error) {
+		limit, current, err := PidsLimit()
+		if err != nil {
+			return false, "", err
+		}
+		ok, detail := checkPidHeadroom(limit, current, minFree)
+		return ok, detail, nil
+	})
+}
+
+// checkPidHeadroom applies the PidHeadroomCondition threshold
+func checkPidHeadroom(limit, current, minFree uint64) (bool, string) {
+	if limit == RLimitInfinity {
+		return true, fmt.Sprintf("pids.max is unlimited, %d in use", current)
+	}
+	var free uint64
+	if current < limit {
+		free = limit - current
+	}
+	return free >= minFree, fmt.Sprintf("%d of %d PIDs free, need %d", free, limit, minFree)
+}
+
+// readPidsLimit reads pids.max and pids.current from the first candidate
+// directory under root holding them, given the contents of
+// /proc/self/cgroup
+func readPidsLimit(root, cgroups string) (limit, current uint64, err error) {
+	for _, dir := range pidsCgroupDirs(root, cgroups) {
+		data, err := os.ReadFile(filepath.Join(dir, "pids.max"))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return 0, 0, err
+		}
+		if limit, err = parsePidsValue(data); err != nil {
+			return 0, 0, fmt.Errorf("pids.max: %w", err)
+		}
+
+		data, err = os.ReadFile(filepath.Join(dir, "pids.current"))
+		if err != nil {
+			return 0, 0, err
+		}
+		if current, err = parsePidsValue(data); err != nil {
+			return 0, 0, fmt.Errorf("pids.current: %w", err)
+		}
+		return limit, current, nil
+	}
+	return 0, 0, ErrNotSupported
+}
+
+// pidsCgroupDirs returns the directories that may hold the pids controller
+// files, most specific first. cgroup v1 mounts the controller at
+// root/pids, v2 uses root itself. Without a cgroup namespace the listed
+// path may not be visible inside a container, so each mount point is
+// tried after the listed path
+func pidsCgroupDirs(root, cgroups string) []string {
+	var dirs []string
+	for _, line := range strings.Split(cgroups, "\n") {
+		parts := strings.SplitN(line, ":", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		var base string
+		switch {
+		case parts[0] == "0" && parts[1] == "":
+			base = root
+		case slices.Contains(strings.Split(parts[1], ","), "pids"):
+			base = filepath.Join(root, "pids")
+		default:
+			continue
+		}
+		dirs = append(dirs, filepath.Join(base, parts[2]), base)
+	}
+	return dirs
+}
+
+// parsePidsValue parses a pids controller value, where "max" means
+// unlimited
+func parsePidsValue(data []byte) (uint64, error) {
+	s := strings.TrimSpace(string(data))
+	if s == "max" {
+		return RLimitInfinity, nil
+	}
+	return strconv.ParseUint(s, 10, 64)
+}
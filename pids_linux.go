@@ -0,0 +1,11 @@
+package release
+
+import "os"
+
+func pidsLimit() (limit, current uint64, err error) {
+	data, err := os.ReadFile(procSelfCgroupPath)
+	if err != nil {
+		return 0, 0, err
+	}
+	return readPidsLimit(cgroupRoot, string(data))
+}
@@ -0,0 +1,7 @@
+//go:build !linux
+
+package release
+
+func pidsLimit() (limit, current uint64, err error) {
+	return 0, 0, ErrNotSupported
+}
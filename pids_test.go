@@ -0,0 +1,101 @@
+package release
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPidsCgroupDirs(t *testing.T) {
+	tests := []struct {
+		name    string
+		cgroups string
+		want    []string
+	}{
+		{"v2", "0::/system.slice/app.service\n", []string{"/cg/system.slice/app.service", "/cg"}},
+		{"v2 namespaced", "0::/\n", []string{"/cg", "/cg"}},
+		{"v1", "12:memory:/docker/abc\n5:pids:/docker/abc\n1:name=systemd:/docker/abc\n", []string{"/cg/pids/docker/abc", "/cg/pids"}},
+		{"v1 shared hierarchy", "3:cpu,pids:/job\n", []string{"/cg/pids/job", "/cg/pids"}},
+		{"no pids controller", "4:memory:/x\n", nil},
+	}
+	for _, tt := range tests {
+		got := pidsCgroupDirs("/cg", tt.cgroups)
+		for i := range got {
+			got[i] = filepath.ToSlash(got[i])
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: pidsCgroupDirs() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestReadPidsLimit(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "app")
+	if err := os.Mkdir(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	write := func(name, value string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(value), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write("pids.max", "512\n")
+	write("pids.current", "37\n")
+	limit, current, err := readPidsLimit(root, "0::/app\n")
+	if err != nil || limit != 512 || current != 37 {
+		t.Errorf("readPidsLimit() = %d, %d, %v; want 512, 37, nil", limit, current, err)
+	}
+
+	write("pids.max", "max\n")
+	if limit, _, err := readPidsLimit(root, "0::/app\n"); err != nil || limit != RLimitInfinity {
+		t.Errorf("readPidsLimit() = %d, %v; want unlimited", limit, err)
+	}
+
+	write("pids.max", "lots\n")
+	if _, _, err := readPidsLimit(root, "0::/app\n"); err == nil {
+		t.Error("readPidsLimit() should reject an invalid pids.max")
+	}
+
+	if _, _, err := readPidsLimit(root, "0::/other\n"); !errors.Is(err, ErrNotSupported) {
+		t.Errorf("Expected ErrNotSupported without pids files, got %v", err)
+	}
+}
+
+func TestCheckPidHeadroom(t *testing.T) {
+	tests := []struct {
+		limit, current, minFree uint64
+		want                    bool
+		detail                  string
+	}{
+		{100, 40, 50, true, "60 of 100 PIDs free, need 50"},
+		{100, 60, 50, false, "40 of 100 PIDs free, need 50"},
+		{100, 120, 1, false, "0 of 100 PIDs free, need 1"},
+		{RLimitInfinity, 5000, 1000, true, "pids.max is unlimited, 5000 in use"},
+	}
+	for _, tt := range tests {
+		got, detail := checkPidHeadroom(tt.limit, tt.current, tt.minFree)
+		if got != tt.want || detail != tt.detail {
+			t.Errorf("checkPidHeadroom(%d, %d, %d) = %v, %q; want %v, %q", tt.limit, tt.current, tt.minFree, got, detail, tt.want, tt.detail)
+		}
+	}
+}
+
+func TestPidHeadroomCondition(t *testing.T) {
+	_, _, err := PidsLimit()
+	r := PidHeadroomCondition(1).evaluate()
+	if err != nil {
+		if r.Error == nil || r.Error.Error() != err.Error() {
+			t.Errorf("Expected the PidsLimit error %v, got %+v", err, r)
+		}
+		return
+	}
+	if !strings.Contains(r.Detail, "PIDs free") && !strings.Contains(r.Detail, "unlimited") {
+		t.Errorf("Unexpected detail: %+v", r)
+	}
+}
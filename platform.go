@@ -0,0 +1,74 @@
+package release
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+// Platform is an OS/architecture pair, e.g. linux/amd64
+type Platform struct {
+	OS   string
+	Arch string
+}
+
+// CurrentPlatform returns the platform the binary is running on
+func CurrentPlatform() Platform {
+	return Platform{OS: runtime.GOOS, Arch: runtime.GOARCH}
+}
+
+// ParsePlatform parses an "os/arch" string such as "linux/amd64". Either
+// part may be "*" to match any value (see Matches)
+func ParsePlatform(s string) (Platform, error) {
+	os, arch, ok := strings.Cut(strings.TrimSpace(s), "/")
+	if !ok || os == "" || arch == "" || strings.Contains(arch, "/") {
+		return Platform{}, fmt.Errorf("invalid platform %q: want os/arch", s)
+	}
+	return Platform{OS: os, Arch: arch}, nil
+}
+
+// String returns the platform as "os/arch"
+func (p Platform) String() string {
+	return p.OS + "/" + p.Arch
+}
+
+// Matches checks if p matches the pattern other. An empty or "*" field in
+// other matches any value, so Platform{OS: "linux"} matches every Linux
+// architecture
+func (p Platform) Matches(other Platform) bool {
+	return matchPlatformField(p.OS, other.OS) && matchPlatformField(p.Arch, other.Arch)
+}
+
+func matchPlatformField(value, pattern string) bool {
+	return pattern == "" || pattern == "*" || pattern == value
+}
+
+// IsPlatformP checks if the current platform matches p (see Matches). It
+// is the Platform-typed counterpart of IsPlatform
+func IsPlatformP(p Platform) bool {
+	return CurrentPlatform().Matches(p)
+}
+
+// MatchPlatforms reports, for each of platforms, whether the current
+// platform matches it (see Matches). Entries may be patterns
+func MatchPlatforms(platforms []Platform) map[Platform]bool {
+	current := CurrentPlatform()
+	matches := make(map[Platform]bool, len(platforms))
+	for _, p := range platforms {
+		matches[p] = current.Matches(p)
+	}
+	return matches
+}
+
+// FirstMatchingPlatform returns the first of platforms that the current
+// platform matches, so more specific entries should come first. It
+// returns false if none match
+func FirstMatchingPlatform(platforms []Platform) (Platform, bool) {
+	current := CurrentPlatform()
+	for _, p := range platforms {
+		if current.Matches(p) {
+			return p, true
+		}
+	}
+	return Platform{}, false
+}
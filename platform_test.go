@@ -0,0 +1,120 @@
+package release
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestParsePlatform(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Platform
+		wantErr bool
+	}{
+		{"linux/amd64", Platform{"linux", "amd64"}, false},
+		{" darwin/arm64 ", Platform{"darwin", "arm64"}, false},
+		{"linux/*", Platform{"linux", "*"}, false},
+		{"linux", Platform{}, true},
+		{"/amd64", Platform{}, true},
+		{"linux/", Platform{}, true},
+		{"linux/amd64/v3", Platform{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParsePlatform(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParsePlatform(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParsePlatform(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlatformMatches(t *testing.T) {
+	p := Platform{"linux", "amd64"}
+
+	tests := []struct {
+		pattern Platform
+		want    bool
+	}{
+		{Platform{"linux", "amd64"}, true},
+		{Platform{"linux", "*"}, true},
+		{Platform{OS: "linux"}, true},
+		{Platform{"*", "amd64"}, true},
+		{Platform{"linux", "arm64"}, false},
+		{Platform{"darwin", "amd64"}, false},
+	}
+
+	for _, tt := range tests {
+		if got := p.Matches(tt.pattern); got != tt.want {
+			t.Errorf("%v.Matches(%v) = %v, want %v", p, tt.pattern, got, tt.want)
+		}
+	}
+
+	if p.String() != "linux/amd64" {
+		t.Errorf("String() = %s", p.String())
+	}
+}
+
+func TestIsPlatformP(t *testing.T) {
+	if !IsPlatformP(Platform{runtime.GOOS, runtime.GOARCH}) {
+		t.Error("IsPlatformP should return true for the current platform")
+	}
+	if IsPlatformP(Platform{"fakeos", "fakearch"}) {
+		t.Error("IsPlatformP should return false for a non-existent platform")
+	}
+	if CurrentPlatform().String() != GetBuildInfo().Platform {
+		t.Error("CurrentPlatform should agree with BuildInfo.Platform")
+	}
+}
+
+func TestMatchPlatforms(t *testing.T) {
+	current := CurrentPlatform()
+	other := Platform{"fakeos", "fakearch"}
+	anyArch := Platform{OS: runtime.GOOS, Arch: "*"}
+
+	got := MatchPlatforms([]Platform{current, other, anyArch})
+	want := map[Platform]bool{current: true, other: false, anyArch: true}
+	if len(got) != len(want) {
+		t.Fatalf("MatchPlatforms() = %v, want %v", got, want)
+	}
+	for p, ok := range want {
+		if got[p] != ok {
+			t.Errorf("MatchPlatforms()[%v] = %v, want %v", p, got[p], ok)
+		}
+	}
+
+	if got := MatchPlatforms(nil); len(got) != 0 {
+		t.Errorf("MatchPlatforms(nil) = %v, want empty", got)
+	}
+}
+
+func TestFirstMatchingPlatform(t *testing.T) {
+	current := CurrentPlatform()
+	other := Platform{"fakeos", "fakearch"}
+	anyArch := Platform{OS: runtime.GOOS, Arch: "*"}
+
+	tests := []struct {
+		name      string
+		platforms []Platform
+		want      Platform
+		wantOK    bool
+	}{
+		{"exact first", []Platform{other, current, anyArch}, current, true},
+		{"pattern first", []Platform{anyArch, current}, anyArch, true},
+		{"none", []Platform{other}, Platform{}, false},
+		{"empty", nil, Platform{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := FirstMatchingPlatform(tt.platforms)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("FirstMatchingPlatform() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package release
+
+import (
+	"fmt"
+	"sync"
+)
+
+// defaultPlatformTiers rates Go's first-class ports as tier 1. Other
+// platforms are unrated until configured with SetPlatformTiers
+var defaultPlatformTiers = map[Platform]int{
+	{OS: "linux", Arch: "386"}:     1,
+	{OS: "linux", Arch: "amd64"}:   1,
+	{OS: "linux", Arch: "arm"}:     1,
+	{OS: "linux", Arch: "arm64"}:   1,
+	{OS: "darwin", Arch: "amd64"}:  1,
+	{OS: "darwin", Arch: "arm64"}:  1,
+	{OS: "windows", Arch: "386"}:   1,
+	{OS: "windows", Arch: "amd64"}: 1,
+}
+
+// platformTiers is the tier table in use
+var (
+	platformTiersMu sync.RWMutex
+	platformTiers   = defaultPlatformTiers
+)
+
+// SetPlatformTiers replaces the table used by PlatformTier. Keys may be
+// patterns (see Platform.Matches), e.g. {OS: "freebsd"} rates every
+// FreeBSD architecture. Tiers start at 1, the best supported; a nil table
+// restores the embedded one, which rates Go's first-class ports as tier 1
+func SetPlatformTiers(tiers map[Platform]int) error {
+	if tiers == nil {
+		tiers = defaultPlatformTiers
+	}
+	copied := make(map[Platform]int, len(tiers))
+	for p, tier := range tiers {
+		if tier < 1 {
+			return fmt.Errorf("platform tier table: %s has tier %d, want 1 or more", p, tier)
+		}
+		copied[p] = tier
+	}
+
+	platformTiersMu.Lock()
+	defer platformTiersMu.Unlock()
+	platformTiers = copied
+	return nil
+}
+
+// PlatformTier returns the support tier of p, where 1 is fully supported
+// and higher tiers are progressively best-effort. An exact entry wins;
+// otherwise the best tier among matching pattern entries is used. It
+// returns 0 for unrated platforms
+func PlatformTier(p Platform) int {
+	platformTiersMu.RLock()
+	defer platformTiersMu.RUnlock()
+
+	if tier, ok := platformTiers[p]; ok {
+		return tier
+	}
+	best := 0
+	for pattern, tier := range platformTiers {
+		if p.Matches(pattern) && (best == 0 || tier < best) {
+			best = tier
+		}
+	}
+	return best
+}
+
+// CurrentPlatformTier returns the support tier of the current platform,
+// or 0 if it is unrated
+func CurrentPlatformTier() int {
+	return PlatformTier(CurrentPlatform())
+}
+
+// MinPlatformTierCondition returns a condition that fails unless the
+// current platform is rated at tier or better, e.g. 1 in production and
+// 2 in staging. Unrated platforms always fail
+func MinPlatformTierCondition(tier int) Condition {
+	return newDetailedCondition("min-platform-tier", fmt.Sprintf("Platform is tier %d or better", tier), func() (bool, string, error) {
+		p := CurrentPlatform()
+		return checkPlatformTier(p, PlatformTier(p), tier)
+	})
+}
+
+// checkPlatformTier compares the tier of p with the required one
+func checkPlatformTier(p Platform, actual, required int) (bool, string, error) {
+	if actual == 0 {
+		return false, fmt.Sprintf("%s has no platform tier", p), nil
+	}
+	return actual <= required, fmt.Sprintf("%s is tier %d, required tier %d or better", p, actual, required), nil
+}
@@ -0,0 +1,90 @@
+package release
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPlatformTier(t *testing.T) {
+	defer SetPlatformTiers(nil)
+
+	if got := PlatformTier(Platform{"linux", "amd64"}); got != 1 {
+		t.Errorf("PlatformTier(linux/amd64) = %d, want 1 from the embedded table", got)
+	}
+	if got := PlatformTier(Platform{"plan9", "386"}); got != 0 {
+		t.Errorf("PlatformTier(plan9/386) = %d, want 0 from the embedded table", got)
+	}
+
+	err := SetPlatformTiers(map[Platform]int{
+		{OS: "linux", Arch: "amd64"}: 1,
+		{OS: "linux", Arch: "*"}:     2,
+		{OS: "freebsd"}:              3,
+		{Arch: "riscv64"}:            2,
+	})
+	if err != nil {
+		t.Fatalf("SetPlatformTiers() error = %v", err)
+	}
+
+	tests := []struct {
+		p    Platform
+		want int
+	}{
+		{Platform{"linux", "amd64"}, 1},
+		{Platform{"linux", "arm64"}, 2},
+		{Platform{"freebsd", "amd64"}, 3},
+		{Platform{"freebsd", "riscv64"}, 2},
+		{Platform{"darwin", "arm64"}, 0},
+	}
+	for _, tt := range tests {
+		if got := PlatformTier(tt.p); got != tt.want {
+			t.Errorf("PlatformTier(%v) = %d, want %d", tt.p, got, tt.want)
+		}
+	}
+	if got, want := CurrentPlatformTier(), PlatformTier(CurrentPlatform()); got != want {
+		t.Errorf("CurrentPlatformTier() = %d, want %d", got, want)
+	}
+
+	if err := SetPlatformTiers(map[Platform]int{{OS: "linux"}: 0}); err == nil {
+		t.Error("SetPlatformTiers() should reject tiers below 1")
+	}
+
+	SetPlatformTiers(nil)
+	if got := PlatformTier(Platform{"darwin", "arm64"}); got != 1 {
+		t.Errorf("SetPlatformTiers(nil) should restore the embedded table, got tier %d", got)
+	}
+}
+
+func TestCheckPlatformTier(t *testing.T) {
+	p := Platform{"linux", "amd64"}
+
+	tests := []struct {
+		actual, required int
+		want             bool
+		detail           string
+	}{
+		{1, 1, true, "tier 1"},
+		{1, 2, true, "tier 1"},
+		{2, 1, false, "tier 2"},
+		{0, 2, false, "no platform tier"},
+	}
+
+	for _, tt := range tests {
+		ok, detail, err := checkPlatformTier(p, tt.actual, tt.required)
+		if err != nil || ok != tt.want || !strings.Contains(detail, tt.detail) {
+			t.Errorf("checkPlatformTier(%d, %d) = %v, %q, %v", tt.actual, tt.required, ok, detail, err)
+		}
+	}
+}
+
+func TestMinPlatformTierCondition(t *testing.T) {
+	defer SetPlatformTiers(nil)
+
+	SetPlatformTiers(map[Platform]int{CurrentPlatform(): 2})
+	if r := MinPlatformTierCondition(2).evaluate(); !r.Passed {
+		t.Errorf("MinPlatformTierCondition(2) = %+v, want pass", r)
+	}
+	r := MinPlatformTierCondition(1).evaluate()
+	if r.Passed || r.Error != nil || r.Detail == "" {
+		t.Errorf("MinPlatformTierCondition(1) = %+v, want failure with detail", r)
+	}
+}
@@ -0,0 +1,105 @@
+package release
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// BuildPolicy declares the build properties a release binary must have.
+// Zero-valued fields are not checked
+type BuildPolicy struct {
+	// MinGoVersion is the minimum Go version the binary must run on
+	MinGoVersion string `json:"min_go_version,omitempty"`
+
+	// RequireTrimpath requires the binary to be built with -trimpath
+	RequireTrimpath bool `json:"require_trimpath,omitempty"`
+
+	// RequireCGODisabled requires the binary to be built with CGO_ENABLED=0
+	RequireCGODisabled bool `json:"require_cgo_disabled,omitempty"`
+
+	// RequireCleanVCS requires embedded VCS info with no uncommitted changes
+	RequireCleanVCS bool `json:"require_clean_vcs,omitempty"`
+
+	// ForbiddenPlatforms lists "os/arch" platforms the binary must not be
+	// built for. Either part may be "*" (see Platform.Matches)
+	ForbiddenPlatforms []string `json:"forbidden_platforms,omitempty"`
+}
+
+// ParsePolicy decodes a JSON build policy such as
+//
+//	{"min_go_version": "1.22", "forbidden_platforms": ["windows/386"]}
+//
+// Unknown fields are rejected, so a policy using requirements this version
+// does not know about fails rather than being silently weakened
+func ParsePolicy(r io.Reader) (*BuildPolicy, error) {
+	var p BuildPolicy
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&p); err != nil {
+		return nil, fmt.Errorf("parsing build policy: %w", err)
+	}
+	return &p, nil
+}
+
+// CheckBuildPolicy evaluates every declared constraint of p against the
+// current build and returns one labeled result per constraint
+func CheckBuildPolicy(p BuildPolicy) TestResults {
+	return buildPolicyConditions(p, GetBuildInfo()).TestAll()
+}
+
+// buildPolicyConditions turns the declared constraints of p into a
+// condition set evaluated against info
+func buildPolicyConditions(p BuildPolicy, info *BuildInfo) *ConditionSet {
+	cs := NewConditionSet()
+
+	if p.MinGoVersion != "" {
+		cs.Add("policy-go-version", fmt.Sprintf("Go version >= %s", p.MinGoVersion), func() (bool, error) {
+			cmp, err := compareVersions(info.GoVersion, p.MinGoVersion)
+			if err != nil {
+				return false, err
+			}
+			return !cmp.IsLess(), nil
+		})
+	}
+
+	if p.RequireTrimpath {
+		cs.Add("policy-trimpath", "Built with -trimpath", func() (bool, error) {
+			return info.Trimpath, nil
+		})
+	}
+
+	if p.RequireCGODisabled {
+		cs.Add("policy-cgo-disabled", "Built with CGO_ENABLED=0", func() (bool, error) {
+			return !info.CGOEnabled, nil
+		})
+	}
+
+	if p.RequireCleanVCS {
+		cs.Add("policy-clean-vcs", "Built from a clean VCS checkout", func() (bool, error) {
+			if info.VCSRevision == "" {
+				return false, errors.New("build has no VCS information")
+			}
+			return !info.VCSModified, nil
+		})
+	}
+
+	if len(p.ForbiddenPlatforms) > 0 {
+		cs.AddDetailed("policy-platform", fmt.Sprintf("Not built for %v", p.ForbiddenPlatforms), func() (bool, string, error) {
+			current := Platform{OS: info.OS, Arch: info.Arch}
+			for _, s := range p.ForbiddenPlatforms {
+				forbidden, err := ParsePlatform(s)
+				if err != nil {
+					return false, "", err
+				}
+				if current.Matches(forbidden) {
+					return false, fmt.Sprintf("%s is forbidden by %s", current, s), nil
+				}
+			}
+			return true, "", nil
+		})
+	}
+
+	return cs
+}
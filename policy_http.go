@@ -0,0 +1,35 @@
+package release
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// maxPolicySize bounds the policy document read by LoadPolicyFromURL
+const maxPolicySize = 1 << 20
+
+// LoadPolicyFromURL fetches a JSON build policy (see ParsePolicy) from a
+// centrally managed endpoint, so release requirements can be tightened
+// fleet-wide without redeploying. The context bounds the request; use
+// context.WithTimeout to avoid hanging on an unreachable endpoint. Any
+// status other than 200 OK is an error
+func LoadPolicyFromURL(ctx context.Context, url string) (*BuildPolicy, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("fetching build policy: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetching build policy: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching build policy from %s: %s", url, resp.Status)
+	}
+	return ParsePolicy(io.LimitReader(resp.Body, maxPolicySize))
+}
@@ -0,0 +1,46 @@
+package release
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestLoadPolicyFromURL(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/policy.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"min_go_version": "1.22", "require_trimpath": true, "forbidden_platforms": ["windows/386"]}`))
+	})
+	mux.HandleFunc("/unknown.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"min_go_version": "1.22", "require_fips": true}`))
+	})
+	mux.HandleFunc("/slow.json", func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	p, err := LoadPolicyFromURL(context.Background(), srv.URL+"/policy.json")
+	if err != nil {
+		t.Fatalf("LoadPolicyFromURL() error = %v", err)
+	}
+	if p.MinGoVersion != "1.22" || !p.RequireTrimpath || len(p.ForbiddenPlatforms) != 1 || p.ForbiddenPlatforms[0] != "windows/386" {
+		t.Errorf("Unexpected policy: %+v", p)
+	}
+
+	if _, err := LoadPolicyFromURL(context.Background(), srv.URL+"/unknown.json"); err == nil {
+		t.Error("A policy with unknown fields should be rejected")
+	}
+	if _, err := LoadPolicyFromURL(context.Background(), srv.URL+"/missing.json"); err == nil {
+		t.Error("A 404 should be an error")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := LoadPolicyFromURL(ctx, srv.URL+"/slow.json"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the context deadline to abort the request, got %v", err)
+	}
+}
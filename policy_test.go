@@ -0,0 +1,111 @@
+package release
+
+import (
+	"runtime/debug"
+	"strings"
+	"testing"
+)
+
+func TestApplySettings(t *testing.T) {
+	info := &BuildInfo{}
+	info.applySettings([]debug.BuildSetting{
+		{Key: "-trimpath", Value: "true"},
+		{Key: "CGO_ENABLED", Value: "0"},
+		{Key: "vcs.revision", Value: "abc123"},
+		{Key: "vcs.modified", Value: "true"},
+		{Key: "vcs.time", Value: "2024-01-01T00:00:00Z"},
+	})
+
+	if !info.Trimpath {
+		t.Error("Trimpath should be true")
+	}
+	if info.CGOEnabled {
+		t.Error("CGOEnabled should be false")
+	}
+	if info.VCSRevision != "abc123" || !info.VCSModified || info.VCSTime == "" {
+		t.Errorf("Unexpected VCS fields: %+v", info)
+	}
+}
+
+func TestCheckBuildPolicy(t *testing.T) {
+	info := &BuildInfo{
+		GoVersion:   "go1.21.5",
+		Trimpath:    true,
+		CGOEnabled:  true,
+		VCSRevision: "abc123",
+	}
+
+	policy := BuildPolicy{
+		MinGoVersion:       "1.21",
+		RequireTrimpath:    true,
+		RequireCGODisabled: true,
+		RequireCleanVCS:    true,
+	}
+	results := buildPolicyConditions(policy, info).TestAll()
+
+	want := map[string]bool{
+		"policy-go-version":   true,
+		"policy-trimpath":     true,
+		"policy-cgo-disabled": false,
+		"policy-clean-vcs":    true,
+	}
+	if len(results) != len(want) {
+		t.Fatalf("Expected %d results, got %d", len(want), len(results))
+	}
+	for _, r := range results {
+		if r.OK() != want[r.Name] {
+			t.Errorf("%s: OK() = %v, want %v (error: %v)", r.Name, r.OK(), want[r.Name], r.Error)
+		}
+	}
+}
+
+func TestForbiddenPlatforms(t *testing.T) {
+	info := &BuildInfo{OS: "windows", Arch: "386"}
+	tests := []struct {
+		forbidden []string
+		wantOK    bool
+		wantErr   bool
+	}{
+		{[]string{"windows/386"}, false, false},
+		{[]string{"linux/*", "windows/*"}, false, false},
+		{[]string{"windows/amd64", "darwin/*"}, true, false},
+		{[]string{"windows"}, false, true},
+	}
+	for _, tt := range tests {
+		results := buildPolicyConditions(BuildPolicy{ForbiddenPlatforms: tt.forbidden}, info).TestAll()
+		if len(results) != 1 || results[0].Name != "policy-platform" {
+			t.Fatalf("Expected a single policy-platform result, got %+v", results)
+		}
+		if r := results[0]; r.OK() != tt.wantOK || (r.Error != nil) != tt.wantErr {
+			t.Errorf("ForbiddenPlatforms %v: got %+v, want OK=%v err=%v", tt.forbidden, r, tt.wantOK, tt.wantErr)
+		}
+	}
+}
+
+func TestParsePolicy(t *testing.T) {
+	p, err := ParsePolicy(strings.NewReader(`{"min_go_version": "1.22", "require_clean_vcs": true}`))
+	if err != nil || p.MinGoVersion != "1.22" || !p.RequireCleanVCS || p.RequireTrimpath {
+		t.Errorf("ParsePolicy() = %+v, %v", p, err)
+	}
+	for _, bad := range []string{`{"min_go_version": 1.22}`, `{"maximum_go_version": "1.30"}`, `not json`} {
+		if _, err := ParsePolicy(strings.NewReader(bad)); err == nil {
+			t.Errorf("ParsePolicy(%s) should fail", bad)
+		}
+	}
+}
+
+func TestCheckBuildPolicySkipsUnsetFields(t *testing.T) {
+	if results := CheckBuildPolicy(BuildPolicy{}); len(results) != 0 {
+		t.Errorf("Empty policy should produce no results, got %d", len(results))
+	}
+
+	results := buildPolicyConditions(BuildPolicy{RequireCleanVCS: true}, &BuildInfo{}).TestAll()
+	if len(results) != 1 || results[0].Error == nil {
+		t.Errorf("Missing VCS info should produce an error, got %+v", results)
+	}
+
+	results = buildPolicyConditions(BuildPolicy{MinGoVersion: "99.0"}, &BuildInfo{GoVersion: "go1.21.0"}).TestAll()
+	if len(results) != 1 || results[0].OK() {
+		t.Errorf("Go 1.21 should not satisfy a 99.0 minimum, got %+v", results)
+	}
+}
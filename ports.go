@@ -0,0 +1,45 @@
+package release
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// EphemeralPortRange returns the local port range used for outbound
+// connections, from /proc/sys/net/ipv4/ip_local_port_range. It returns
+// ErrNotSupported on non-Linux platforms
+func EphemeralPortRange() (low, high int, err error) {
+	return ephemeralPortRange()
+}
+
+// MinEphemeralPortsCondition returns a condition that fails when the
+// ephemeral port range holds fewer than n ports
+func MinEphemeralPortsCondition(n int) Condition {
+	return newDetailedCondition("min-ephemeral-ports", fmt.Sprintf("At least %d ephemeral ports available", n), func() (bool, string, error) {
+		low, high, err := EphemeralPortRange()
+		if err != nil {
+			return false, "", err
+		}
+		count := high - low + 1
+		return count >= n, fmt.Sprintf("ephemeral port range %d-%d has %d ports, need %d", low, high, count, n), nil
+	})
+}
+
+// parsePortRange parses the "low high" contents of ip_local_port_range
+func parsePortRange(data string) (low, high int, err error) {
+	fields := strings.Fields(data)
+	if len(fields) != 2 {
+		return 0, 0, fmt.Errorf("invalid port range %q", data)
+	}
+	if low, err = strconv.Atoi(fields[0]); err != nil {
+		return 0, 0, fmt.Errorf("invalid port range %q: %w", data, err)
+	}
+	if high, err = strconv.Atoi(fields[1]); err != nil {
+		return 0, 0, fmt.Errorf("invalid port range %q: %w", data, err)
+	}
+	if low < 0 || high < low {
+		return 0, 0, fmt.Errorf("invalid port range %q", data)
+	}
+	return low, high, nil
+}
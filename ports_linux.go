@@ -0,0 +1,14 @@
+package release
+
+import "os"
+
+// portRangePath is the procfs file holding the ephemeral port range
+var portRangePath = "/proc/sys/net/ipv4/ip_local_port_range"
+
+func ephemeralPortRange() (low, high int, err error) {
+	data, err := os.ReadFile(portRangePath)
+	if err != nil {
+		return 0, 0, err
+	}
+	return parsePortRange(string(data))
+}
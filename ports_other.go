@@ -0,0 +1,7 @@
+//go:build !linux
+
+package release
+
+func ephemeralPortRange() (low, high int, err error) {
+	return 0, 0, ErrNotSupported
+}
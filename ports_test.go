@@ -0,0 +1,53 @@
+package release
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParsePortRange(t *testing.T) {
+	tests := []struct {
+		input     string
+		low, high int
+		wantErr   bool
+	}{
+		{"32768\t60999\n", 32768, 60999, false},
+		{"1024 65535", 1024, 65535, false},
+		{"60999 32768", 0, 0, true},
+		{"32768", 0, 0, true},
+		{"a b", 0, 0, true},
+		{"", 0, 0, true},
+	}
+
+	for _, tt := range tests {
+		low, high, err := parsePortRange(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parsePortRange(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if low != tt.low || high != tt.high {
+			t.Errorf("parsePortRange(%q) = %d-%d, want %d-%d", tt.input, low, high, tt.low, tt.high)
+		}
+	}
+}
+
+func TestMinEphemeralPortsCondition(t *testing.T) {
+	low, high, err := EphemeralPortRange()
+	if errors.Is(err, ErrNotSupported) {
+		if r := MinEphemeralPortsCondition(1).evaluate(); !errors.Is(r.Error, ErrNotSupported) {
+			t.Errorf("Expected ErrNotSupported, got %+v", r)
+		}
+		return
+	}
+	if err != nil {
+		t.Skipf("cannot read port range: %v", err)
+	}
+
+	count := high - low + 1
+	if r := MinEphemeralPortsCondition(count).evaluate(); !r.Passed {
+		t.Errorf("MinEphemeralPortsCondition(%d) should pass: %+v", count, r)
+	}
+	if r := MinEphemeralPortsCondition(count + 1).evaluate(); r.Passed {
+		t.Errorf("MinEphemeralPortsCondition(%d) should fail: %+v", count+1, r)
+	}
+}
@@ -0,0 +1,44 @@
+package release
+
+import (
+	"net/http"
+	"strings"
+)
+
+// pprofIndexPath is the path net/http/pprof registers its index under
+const pprofIndexPath = "/debug/pprof/"
+
+// IsDefaultPprofRegistered reports whether net/http/pprof's handlers are
+// registered on http.DefaultServeMux, which happens as a side effect of
+// importing the package anywhere in the binary.
+//
+// Detection asks DefaultServeMux which pattern would serve a request for
+// /debug/pprof/, without calling any handler. pprof registers exactly that
+// pattern (prefixed with "GET " under Go 1.22 mux semantics), so catch-all
+// handlers such as "/" are not mistaken for it. Handlers registered on
+// other muxes are not detected
+func IsDefaultPprofRegistered() bool {
+	return pprofRegistered(http.DefaultServeMux)
+}
+
+// NoPprofCondition returns a condition that fails when pprof's handlers
+// are registered on http.DefaultServeMux (see IsDefaultPprofRegistered)
+func NoPprofCondition() Condition {
+	return newDetailedCondition("no-pprof", "pprof is not registered on the default mux", func() (bool, string, error) {
+		if IsDefaultPprofRegistered() {
+			return false, "net/http/pprof handlers are registered on http.DefaultServeMux at " + pprofIndexPath, nil
+		}
+		return true, "no handler registered at " + pprofIndexPath, nil
+	})
+}
+
+// pprofRegistered reports whether mux routes the pprof index to a pattern
+// registered for it
+func pprofRegistered(mux *http.ServeMux) bool {
+	req, err := http.NewRequest(http.MethodGet, pprofIndexPath, nil)
+	if err != nil {
+		return false
+	}
+	_, pattern := mux.Handler(req)
+	return strings.HasSuffix(pattern, pprofIndexPath)
+}
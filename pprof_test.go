@@ -0,0 +1,34 @@
+package release
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestPprofRegistered(t *testing.T) {
+	handler := http.HandlerFunc(func(http.ResponseWriter, *http.Request) {})
+
+	if pprofRegistered(http.NewServeMux()) {
+		t.Error("An empty mux should not report pprof")
+	}
+
+	catchAll := http.NewServeMux()
+	catchAll.Handle("/", handler)
+	catchAll.Handle("/debug/", handler)
+	if pprofRegistered(catchAll) {
+		t.Error("Catch-all handlers should not be mistaken for pprof")
+	}
+
+	withPprof := http.NewServeMux()
+	withPprof.Handle("/debug/pprof/", handler)
+	if !pprofRegistered(withPprof) {
+		t.Error("Expected pprof to be detected")
+	}
+}
+
+func TestNoPprofCondition(t *testing.T) {
+	// This package does not import net/http/pprof
+	if r := NoPprofCondition().evaluate(); !r.Passed {
+		t.Errorf("NoPprofCondition() = %+v", r)
+	}
+}
@@ -0,0 +1,50 @@
+package release
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io"
+	"time"
+)
+
+// cryptoRandReader is the entropy source probed by CryptoRandAvailableCondition
+var cryptoRandReader io.Reader = rand.Reader
+
+// CryptoRandAvailableCondition returns a condition that reads a few bytes
+// from crypto/rand and fails if the read errors or does not complete
+// within timeout
+//
+// A read that blocks past the timeout is abandoned, not cancelled: its
+// goroutine stays blocked until the entropy source returns
+func CryptoRandAvailableCondition(timeout time.Duration) Condition {
+	return Condition{
+		Name:        "crypto-rand",
+		Description: fmt.Sprintf("crypto/rand returns data within %s", timeout),
+		Check: func() (bool, error) {
+			return cryptoRandAvailable(cryptoRandReader, timeout)
+		},
+	}
+}
+
+// cryptoRandAvailable reads from r with a timeout
+func cryptoRandAvailable(r io.Reader, timeout time.Duration) (bool, error) {
+	done := make(chan error, 1)
+	go func() {
+		buf := make([]byte, 16)
+		_, err := io.ReadFull(r, buf)
+		done <- err
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			return false, fmt.Errorf("reading crypto/rand: %w", err)
+		}
+		return true, nil
+	case <-timer.C:
+		return false, fmt.Errorf("reading crypto/rand: blocked for more than %s", timeout)
+	}
+}
@@ -0,0 +1,43 @@
+package release
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+type blockingReader struct{ release chan struct{} }
+
+func (r blockingReader) Read(p []byte) (int, error) {
+	<-r.release
+	return 0, errors.New("released")
+}
+
+type errReader struct{}
+
+func (errReader) Read(p []byte) (int, error) {
+	return 0, errors.New("no entropy")
+}
+
+func TestCryptoRandAvailableCondition(t *testing.T) {
+	cond := CryptoRandAvailableCondition(time.Second)
+	passed, err := cond.Check()
+	if err != nil || !passed {
+		t.Errorf("Check() = %v, %v; want true, nil", passed, err)
+	}
+}
+
+func TestCryptoRandAvailableFailures(t *testing.T) {
+	passed, err := cryptoRandAvailable(errReader{}, time.Second)
+	if passed || err == nil {
+		t.Errorf("erroring reader: got %v, %v; want false and an error", passed, err)
+	}
+
+	r := blockingReader{release: make(chan struct{})}
+	defer close(r.release)
+	passed, err = cryptoRandAvailable(r, 10*time.Millisecond)
+	if passed || err == nil || !strings.Contains(err.Error(), "blocked") {
+		t.Errorf("blocking reader: got %v, %v; want false and a timeout error", passed, err)
+	}
+}
@@ -0,0 +1,56 @@
+package release
+
+import (
+	"context"
+
+	"golang.org/x/time/rate"
+)
+
+// TestAllRateLimited tests all conditions like TestAll, but starts at most
+// rps checks per second, using a token bucket with a burst of one, to
+// protect fragile upstreams during a large gate run. Every check still
+// runs. The limit spaces out the starts of checks, so it holds across all
+// checks of the run however long each takes. Skipped conditions do not
+// consume tokens. An rps of zero or less means no limit. Checks run one
+// at a time; use TestAllParallelRateLimited to also run them concurrently
+func (cs *ConditionSet) TestAllRateLimited(rps float64) TestResults {
+	limiter := newRunLimiter(rps)
+
+	results := make(TestResults, 0, len(cs.conditions))
+	skips := cs.skipReasons()
+	ctx := beginRun()
+
+	for _, cond := range cs.conditions {
+		if _, skipped := skips[cond.Name]; !skipped {
+			waitForToken(limiter)
+		}
+		results = append(results, evaluateUnlessSkipped(ctx, cond, skips))
+	}
+
+	return results
+}
+
+// TestAllParallelRateLimited combines TestAllParallel and
+// TestAllRateLimited: up to maxConcurrency checks run at once, and one
+// limiter shared by the whole run starts at most rps checks per second.
+// Calling TestAllRateLimited from concurrent goroutines instead would give
+// each its own limiter and multiply the rate
+func (cs *ConditionSet) TestAllParallelRateLimited(maxConcurrency int, rps float64) TestResults {
+	return cs.testAllParallel(maxConcurrency, newRunLimiter(rps))
+}
+
+// newRunLimiter returns the limiter of a rate-limited run, starting at
+// most rps checks per second, or any number for an rps of zero or less
+func newRunLimiter(rps float64) *rate.Limiter {
+	limit := rate.Inf
+	if rps > 0 {
+		limit = rate.Limit(rps)
+	}
+	return rate.NewLimiter(limit, 1)
+}
+
+// waitForToken blocks until limiter allows the next check to start
+func waitForToken(limiter *rate.Limiter) {
+	// Wait only fails for a cancelled context or a burst of zero
+	_ = limiter.Wait(context.Background())
+}
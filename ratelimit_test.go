@@ -0,0 +1,78 @@
+package release
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestTestAllRateLimited(t *testing.T) {
+	var starts []time.Time
+	cs := NewConditionSet()
+	for _, name := range []string{"a", "b", "c", "d"} {
+		cs.Add(name, "Probe "+name, func() (bool, error) {
+			starts = append(starts, time.Now())
+			return true, nil
+		})
+	}
+	cs.SkipChecks("d")
+
+	results := cs.TestAllRateLimited(50)
+	if len(results) != 4 || !results.AllPassed() || !results[3].Skipped {
+		t.Fatalf("Unexpected results: %+v", results)
+	}
+	if len(starts) != 3 {
+		t.Fatalf("Expected 3 checks to run, got %d", len(starts))
+	}
+	// 50 rps spaces starts 20ms apart; allow for timer slack
+	if elapsed := starts[2].Sub(starts[0]); elapsed < 30*time.Millisecond {
+		t.Errorf("3 checks at 50 rps started within %s, want at least 40ms", elapsed)
+	}
+
+	starts = nil
+	begin := time.Now()
+	if results := cs.TestAllRateLimited(0); len(results) != 4 {
+		t.Fatalf("Unexpected results: %+v", results)
+	}
+	if elapsed := time.Since(begin); elapsed > time.Second {
+		t.Errorf("rps 0 should not throttle, took %s", elapsed)
+	}
+}
+
+func TestTestAllParallelRateLimited(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		starts []time.Time
+	)
+	cs := NewConditionSet()
+	for _, name := range []string{"a", "b", "c", "d"} {
+		cs.Add(name, "Probe "+name, func() (bool, error) {
+			mu.Lock()
+			starts = append(starts, time.Now())
+			mu.Unlock()
+			return true, nil
+		})
+	}
+	cs.SkipChecks("d")
+
+	results := cs.TestAllParallelRateLimited(4, 50)
+	if len(results) != 4 || !results.AllPassed() || !results[3].Skipped {
+		t.Fatalf("Unexpected results: %+v", results)
+	}
+	if len(starts) != 3 {
+		t.Fatalf("Expected 3 checks to run, got %d", len(starts))
+	}
+	// The limiter is shared by all goroutines, so starts are still spaced
+	first, last := starts[0], starts[0]
+	for _, s := range starts {
+		if s.Before(first) {
+			first = s
+		}
+		if s.After(last) {
+			last = s
+		}
+	}
+	if elapsed := last.Sub(first); elapsed < 30*time.Millisecond {
+		t.Errorf("3 parallel checks at 50 rps started within %s, want at least 40ms", elapsed)
+	}
+}
@@ -0,0 +1,118 @@
+package release
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// EnvironmentEnvVar is the env var naming the deployment environment,
+// e.g. RELEASE_ENV=production
+const EnvironmentEnvVar = "RELEASE_ENV"
+
+// DetectEnvironment returns the deployment environment from
+// EnvironmentEnvVar, lower-cased. It returns "" when the variable is unset.
+// Use an EnvironmentResolver to layer overrides, a config file or a default
+func DetectEnvironment() Environment {
+	return EnvironmentResolver{}.Resolve()
+}
+
+// ReadinessReport combines everything needed to decide whether a service
+// is ready: the build, where it runs, the condition results and the
+// verdict. It is the object to serialize for readiness endpoints and
+// audit records
+type ReadinessReport struct {
+	BuildInfo   *BuildInfo  `json:"build_info"`
+	Environment Environment `json:"environment,omitempty"`
+	Results     TestResults `json:"results"`
+	Ready       bool        `json:"ready"`
+	GeneratedAt time.Time   `json:"generated_at"`
+
+	// Metadata is free-form context about the run, e.g. the release
+	// version, git branch or operator, carried into every serialized form
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// WithMetadata returns a copy of the report with metadata added to its
+// Metadata, overwriting existing keys. The original report is not modified
+func (report ReadinessReport) WithMetadata(metadata map[string]string) ReadinessReport {
+	merged := make(map[string]string, len(report.Metadata)+len(metadata))
+	for k, v := range report.Metadata {
+		merged[k] = v
+	}
+	for k, v := range metadata {
+		merged[k] = v
+	}
+	report.Metadata = merged
+	return report
+}
+
+// ToJUnit renders the report's results as JUnit XML (see
+// TestResults.ToJUnit), with the metadata, environment and verdict
+// recorded as <properties> of the test suite
+func (report ReadinessReport) ToJUnit(suiteName string) ([]byte, error) {
+	properties := make(map[string]string, len(report.Metadata)+2)
+	for k, v := range report.Metadata {
+		properties[k] = v
+	}
+	if report.Environment != "" {
+		properties["environment"] = string(report.Environment)
+	}
+	properties["ready"] = strconv.FormatBool(report.Ready)
+	return report.Results.toJUnit(suiteName, properties)
+}
+
+// GenerateReport runs all conditions and returns a ReadinessReport. The
+// report is ready when every result is OK. GeneratedAt is in UTC
+func (cs *ConditionSet) GenerateReport() ReadinessReport {
+	results := cs.TestAll()
+	return ReadinessReport{
+		BuildInfo:   GetBuildInfo(),
+		Environment: DetectEnvironment(),
+		Results:     results,
+		Ready:       results.AllPassed(),
+		GeneratedAt: now().UTC(),
+	}
+}
+
+// MarshalJSON encodes the report with a top-level schema_version, which
+// is not repeated in the nested build_info
+func (report ReadinessReport) MarshalJSON() ([]byte, error) {
+	type plain ReadinessReport
+	return json.Marshal(struct {
+		SchemaVersion string `json:"schema_version"`
+		plain
+		// BuildInfo shadows the embedded field of the same name
+		BuildInfo *nestedBuildInfo `json:"build_info"`
+	}{SchemaVersion, plain(report), (*nestedBuildInfo)(report.BuildInfo)})
+}
+
+// Encode writes the report as an indented JSON document, preserving every
+// field including per-condition details, errors and timings, so a run
+// captured on one host can be analyzed elsewhere with
+// DecodeReadinessReport
+func (report ReadinessReport) Encode(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(report)
+}
+
+// DecodeReadinessReport reads a report written by Encode. Condition
+// errors are restored as plain errors carrying the original message.
+// Reports with a schema_version other than SchemaVersion are rejected;
+// reports without one predate versioning and are accepted
+func DecodeReadinessReport(r io.Reader) (*ReadinessReport, error) {
+	var doc struct {
+		SchemaVersion string `json:"schema_version"`
+		ReadinessReport
+	}
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("decoding readiness report: %w", err)
+	}
+	if doc.SchemaVersion != "" && doc.SchemaVersion != SchemaVersion {
+		return nil, fmt.Errorf("decoding readiness report: unsupported schema version %q, want %q", doc.SchemaVersion, SchemaVersion)
+	}
+	return &doc.ReadinessReport, nil
+}
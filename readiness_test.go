@@ -0,0 +1,166 @@
+package release
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDetectEnvironment(t *testing.T) {
+	t.Setenv(EnvironmentEnvVar, " Production ")
+	if got := DetectEnvironment(); got != EnvProduction {
+		t.Errorf("DetectEnvironment() = %q, want %q", got, EnvProduction)
+	}
+	t.Setenv(EnvironmentEnvVar, "")
+	if got := DetectEnvironment(); got != "" {
+		t.Errorf("DetectEnvironment() with no env var = %q, want empty", got)
+	}
+}
+
+func TestGenerateReport(t *testing.T) {
+	fixed := time.Date(2024, 3, 1, 17, 0, 0, 0, time.FixedZone("UTC+5", 5*60*60))
+	now = func() time.Time { return fixed }
+	defer func() { now = time.Now }()
+	t.Setenv(EnvironmentEnvVar, "staging")
+
+	cs := NewConditionSet()
+	cs.Add("ok", "Always passes", func() (bool, error) { return true, nil })
+	report := cs.GenerateReport()
+	if !report.Ready || len(report.Results) != 1 {
+		t.Errorf("Unexpected report: %+v", report)
+	}
+	if report.BuildInfo == nil || report.Environment != EnvStaging {
+		t.Errorf("Report missing build info or environment: %+v", report)
+	}
+	if report.GeneratedAt.Location() != time.UTC || !report.GeneratedAt.Equal(fixed) {
+		t.Errorf("GeneratedAt = %v, want %v in UTC", report.GeneratedAt, fixed)
+	}
+
+	data, err := json.Marshal(report)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if !strings.Contains(string(data), `"generated_at":"2024-03-01T12:00:00Z"`) {
+		t.Errorf("Unexpected JSON: %s", data)
+	}
+
+	cs.Add("broken", "Always errors", func() (bool, error) { return false, errors.New("down") })
+	if cs.GenerateReport().Ready {
+		t.Error("A report with a failing condition should not be ready")
+	}
+}
+
+func TestReadinessReportMetadata(t *testing.T) {
+	t.Setenv(EnvironmentEnvVar, "production")
+	cs := NewConditionSet()
+	cs.Add("ok", "Always passes", func() (bool, error) { return true, nil })
+
+	base := cs.GenerateReport().WithMetadata(map[string]string{"version": "1.4.0", "branch": "main"})
+	report := base.WithMetadata(map[string]string{"operator": "alice", "version": "1.4.1"})
+	if len(base.Metadata) != 2 || base.Metadata["version"] != "1.4.0" {
+		t.Errorf("WithMetadata modified the original report: %v", base.Metadata)
+	}
+	if len(report.Metadata) != 3 || report.Metadata["version"] != "1.4.1" {
+		t.Errorf("Unexpected merged metadata: %v", report.Metadata)
+	}
+
+	data, err := json.Marshal(report)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if !strings.Contains(string(data), `"metadata":{"branch":"main","operator":"alice","version":"1.4.1"}`) {
+		t.Errorf("Metadata missing from JSON: %s", data)
+	}
+
+	junit, err := report.ToJUnit("release")
+	if err != nil {
+		t.Fatalf("ToJUnit() error = %v", err)
+	}
+	for _, want := range []string{
+		`<property name="branch" value="main"></property>`,
+		`<property name="environment" value="production"></property>`,
+		`<property name="ready" value="true"></property>`,
+	} {
+		if !strings.Contains(string(junit), want) {
+			t.Errorf("ToJUnit() output missing %s:\n%s", want, junit)
+		}
+	}
+	if plain, _ := report.Results.ToJUnit("release"); strings.Contains(string(plain), "<properties") {
+		t.Errorf("TestResults.ToJUnit() should not emit properties:\n%s", plain)
+	}
+}
+
+func TestReadinessReportEncodeDecode(t *testing.T) {
+	started := time.Date(2024, 3, 1, 12, 0, 0, 123456789, time.UTC)
+	report := ReadinessReport{
+		BuildInfo:   GetBuildInfo(),
+		Environment: EnvProduction,
+		Results: TestResults{
+			{Name: "ok", Description: "Passes", Passed: true, Detail: "fine", StartedAt: started, Duration: 1500 * time.Microsecond},
+			{Name: "db", Error: errors.New("connection refused"), ErrorIsPass: true, StartedAt: started, Duration: time.Second},
+			{Name: "dns", Skipped: true, SkipReason: "skipped via SkipChecks"},
+		},
+		Ready:       true,
+		GeneratedAt: started,
+		Metadata:    map[string]string{"version": "1.4.0"},
+	}
+
+	var buf bytes.Buffer
+	if err := report.Encode(&buf); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	decoded, err := DecodeReadinessReport(&buf)
+	if err != nil {
+		t.Fatalf("DecodeReadinessReport() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(decoded.BuildInfo, report.BuildInfo) {
+		t.Errorf("BuildInfo = %+v, want %+v", decoded.BuildInfo, report.BuildInfo)
+	}
+	if decoded.Environment != report.Environment || decoded.Ready != report.Ready ||
+		!decoded.GeneratedAt.Equal(report.GeneratedAt) || !reflect.DeepEqual(decoded.Metadata, report.Metadata) {
+		t.Errorf("Decoded report = %+v, want %+v", decoded, report)
+	}
+	if len(decoded.Results) != len(report.Results) {
+		t.Fatalf("Decoded %d results, want %d", len(decoded.Results), len(report.Results))
+	}
+	for i, want := range report.Results {
+		got := decoded.Results[i]
+		if (got.Error == nil) != (want.Error == nil) || (got.Error != nil && got.Error.Error() != want.Error.Error()) {
+			t.Errorf("Result %d error = %v, want %v", i, got.Error, want.Error)
+		}
+		got.Error, want.Error = nil, nil
+		if !got.StartedAt.Equal(want.StartedAt) {
+			t.Errorf("Result %d StartedAt = %v, want %v", i, got.StartedAt, want.StartedAt)
+		}
+		got.StartedAt, want.StartedAt = time.Time{}, time.Time{}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Result %d = %+v, want %+v", i, got, want)
+		}
+	}
+
+	if _, err := DecodeReadinessReport(strings.NewReader("{")); err == nil {
+		t.Error("DecodeReadinessReport() should reject invalid JSON")
+	}
+}
+
+func TestDecodeReadinessReportSchemaVersion(t *testing.T) {
+	tests := []struct {
+		doc     string
+		wantErr bool
+	}{
+		{`{"schema_version": "1", "ready": true}`, false},
+		{`{"ready": true}`, false},
+		{`{"schema_version": "2", "ready": true}`, true},
+	}
+	for _, tt := range tests {
+		_, err := DecodeReadinessReport(strings.NewReader(tt.doc))
+		if (err != nil) != tt.wantErr {
+			t.Errorf("DecodeReadinessReport(%s) error = %v, wantErr %v", tt.doc, err, tt.wantErr)
+		}
+	}
+}
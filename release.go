@@ -1,27 +1,38 @@
 package release
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"regexp"
 	"runtime"
 	"runtime/debug"
 	"strconv"
 	"strings"
-
-	"golang.org/x/mod/semver"
+	"time"
 )
 
 // BuildInfo contains information about the build
 type BuildInfo struct {
-	GoVersion   string
-	Compiler    string
-	Platform    string
-	OS          string
-	Arch        string
-	NumCPU      int
-	BuildTime   string
-	VCSRevision string
-	VCSModified bool
-	VCSTime     string
+	GoVersion     string
+	Compiler      string
+	Platform      string
+	OS            string
+	OSFamily      string
+	Arch          string
+	NumCPU        int
+	BuildTime     string
+	ModulePath    string
+	ModuleVersion string
+	VCSRevision   string
+	VCSModified   bool
+	VCSTime       string
+	VCSTag        string
+	Trimpath      bool
+	CGOEnabled    bool
+	// Dependencies lists the modules compiled into the binary, sorted by
+	// module path
+	Dependencies []Module
 }
 
 // GetBuildInfo returns detailed build information
@@ -31,27 +42,42 @@ func GetBuildInfo() *BuildInfo {
 		Compiler:  runtime.Compiler,
 		Platform:  fmt.Sprintf("%s/%s", runtime.GOOS, runtime.GOARCH),
 		OS:        runtime.GOOS,
+		OSFamily:  OSFamily(),
 		Arch:      runtime.GOARCH,
 		NumCPU:    runtime.NumCPU(),
 	}
 
-	// Get VCS information from build info
+	// Get VCS and build flag information from build info
 	if buildInfo, ok := debug.ReadBuildInfo(); ok {
-		for _, setting := range buildInfo.Settings {
-			switch setting.Key {
-			case "vcs.revision":
-				info.VCSRevision = setting.Value
-			case "vcs.modified":
-				info.VCSModified = setting.Value == "true"
-			case "vcs.time":
-				info.VCSTime = setting.Value
-			}
-		}
+		info.ModulePath = buildInfo.Main.Path
+		info.ModuleVersion = buildInfo.Main.Version
+		info.Dependencies = dependencies(buildInfo.Deps)
+		info.applySettings(buildInfo.Settings)
 	}
 
 	return info
 }
 
+// applySettings fills in the fields derived from build settings
+func (info *BuildInfo) applySettings(settings []debug.BuildSetting) {
+	for _, setting := range settings {
+		switch setting.Key {
+		case "vcs.revision":
+			info.VCSRevision = setting.Value
+		case "vcs.modified":
+			info.VCSModified = setting.Value == "true"
+		case "vcs.time":
+			info.VCSTime = setting.Value
+		case "vcs.tag":
+			info.VCSTag = setting.Value
+		case "-trimpath":
+			info.Trimpath = setting.Value == "true"
+		case "CGO_ENABLED":
+			info.CGOEnabled = setting.Value == "1"
+		}
+	}
+}
+
 // IsDebugMode checks if the binary is built in debug mode (no optimizations)
 // This is a heuristic based on available information
 func IsDebugMode() bool {
@@ -66,37 +92,59 @@ func IsDebugMode() bool {
 	return false
 }
 
-// CompareGoVersion compares the current Go version with a target version
-// Returns:
-//
-//	-1 if current < target
-//	 0 if current == target
-//	 1 if current > target
-func CompareGoVersion(targetVersion string) (int, error) {
-	current := runtime.Version()
+// Ordering is the result of comparing two versions
+type Ordering int
 
-	// Normalize versions for semver comparison
-	currentNorm := normalizeGoVersion(current)
-	targetNorm := normalizeGoVersion(targetVersion)
+const (
+	Less    Ordering = -1
+	Equal   Ordering = 0
+	Greater Ordering = 1
+)
 
-	if !semver.IsValid(currentNorm) {
-		return 0, fmt.Errorf("invalid current version: %s", current)
-	}
-	if !semver.IsValid(targetNorm) {
-		return 0, fmt.Errorf("invalid target version: %s", targetVersion)
+// IsLess returns true if the first version is older than the second
+func (o Ordering) IsLess() bool { return o == Less }
+
+// IsEqual returns true if the versions are the same
+func (o Ordering) IsEqual() bool { return o == Equal }
+
+// IsGreater returns true if the first version is newer than the second
+func (o Ordering) IsGreater() bool { return o == Greater }
+
+// String returns "less", "equal" or "greater"
+func (o Ordering) String() string {
+	switch o {
+	case Less:
+		return "less"
+	case Equal:
+		return "equal"
+	case Greater:
+		return "greater"
+	default:
+		return fmt.Sprintf("Ordering(%d)", int(o))
 	}
+}
 
-	return semver.Compare(currentNorm, targetNorm), nil
+// CompareGoVersion compares the current Go version with a target version
+// Returns:
+//
+//	Less    (-1) if current < target
+//	Equal   ( 0) if current == target
+//	Greater ( 1) if current > target
+func CompareGoVersion(targetVersion string) (Ordering, error) {
+	return compareVersions(runtime.Version(), targetVersion)
 }
 
-// normalizeGoVersion converts Go version format to semver format
-// e.g., "go1.21.0" -> "v1.21.0"
-func normalizeGoVersion(version string) string {
-	version = strings.TrimPrefix(version, "go")
-	if !strings.HasPrefix(version, "v") {
-		version = "v" + version
+// compareVersions compares two Go version strings (see ParseGoVersion)
+func compareVersions(current, targetVersion string) (Ordering, error) {
+	cur, err := ParseGoVersion(current)
+	if err != nil {
+		return 0, fmt.Errorf("invalid current version: %w", err)
+	}
+	target, err := ParseGoVersion(targetVersion)
+	if err != nil {
+		return 0, fmt.Errorf("invalid target version: %w", err)
 	}
-	return version
+	return Ordering(cur.Compare(target)), nil
 }
 
 // IsGoVersionAtLeast checks if the current Go version is at least the specified version
@@ -105,12 +153,115 @@ func IsGoVersionAtLeast(minVersion string) (bool, error) {
 	if err != nil {
 		return false, err
 	}
-	return cmp >= 0, nil
+	return !cmp.IsLess(), nil
+}
+
+// IsGoVersionAtMost checks if the current Go version is at most the
+// specified version, e.g. for code relying on an API removed in a later
+// release. It accepts the same formats as CompareGoVersion
+func IsGoVersionAtMost(maxVersion string) (bool, error) {
+	cmp, err := CompareGoVersion(maxVersion)
+	if err != nil {
+		return false, err
+	}
+	return !cmp.IsGreater(), nil
+}
+
+// IsGoVersionBetween checks if the current Go version is within the
+// inclusive range [minVersion, maxVersion]. It returns an error if
+// either bound is invalid or minVersion is newer than maxVersion
+func IsGoVersionBetween(minVersion, maxVersion string) (bool, error) {
+	return goVersionBetween(runtime.Version(), minVersion, maxVersion)
+}
+
+// goVersionBetween checks if current is within [minVersion, maxVersion]
+func goVersionBetween(current, minVersion, maxVersion string) (bool, error) {
+	lower, err := compareVersions(current, minVersion)
+	if err != nil {
+		return false, err
+	}
+	upper, err := compareVersions(current, maxVersion)
+	if err != nil {
+		return false, err
+	}
+	// Both bounds are valid at this point
+	if order, _ := compareVersions(minVersion, maxVersion); order.IsGreater() {
+		return false, fmt.Errorf("invalid range: min %s > max %s", minVersion, maxVersion)
+	}
+	return !lower.IsLess() && !upper.IsGreater(), nil
+}
+
+// CompareGoVersionParts compares the current Go version with the version
+// major.minor.patch, without formatting the numbers into a version string
+// first. A pre-release runtime such as go1.22rc1 is older than 1.22.0.
+// It returns an error for negative components or a runtime version that
+// cannot be parsed, e.g. a development build
+func CompareGoVersionParts(major, minor, patch int) (Ordering, error) {
+	return compareGoVersionParts(runtime.Version(), major, minor, patch)
+}
+
+// compareGoVersionParts compares a Go version string with major.minor.patch
+func compareGoVersionParts(current string, major, minor, patch int) (Ordering, error) {
+	if major < 0 || minor < 0 || patch < 0 {
+		return 0, fmt.Errorf("invalid target version: %d.%d.%d", major, minor, patch)
+	}
+	cur, err := ParseGoVersion(current)
+	if err != nil {
+		return 0, err
+	}
+	return Ordering(cur.Compare(GoVersion{Major: major, Minor: minor, Patch: patch})), nil
 }
 
 // GetGoMajorMinor returns the major and minor version of the current Go runtime
 func GetGoMajorMinor() (major, minor int, err error) {
-	version := runtime.Version()
+	return parseGoMajorMinor(runtime.Version())
+}
+
+// GetGoVersionParts returns the major, minor and patch version of the
+// current Go runtime. The patch is 0 for versions without one, such as
+// go1.21, and for pre-releases such as go1.22rc1, which precede 1.22.0
+func GetGoVersionParts() (major, minor, patch int, err error) {
+	return goVersionParts(runtime.Version())
+}
+
+// goVersionParts splits a Go version string into its numeric parts
+func goVersionParts(version string) (major, minor, patch int, err error) {
+	v, err := ParseGoVersion(version)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	return v.Major, v.Minor, v.Patch, nil
+}
+
+// SameMinorLine checks if the current Go version shares the major.minor
+// line of the target version, regardless of patch level
+// e.g., go1.21.5 is on the same minor line as "1.21.0" and "go1.21"
+func SameMinorLine(target string) (bool, error) {
+	return sameMinorLine(runtime.Version(), target)
+}
+
+// sameMinorLine compares the major.minor lines of two Go versions
+func sameMinorLine(current, target string) (bool, error) {
+	major, minor, err := parseGoMajorMinor(current)
+	if err != nil {
+		return false, err
+	}
+
+	v, err := ParseGoVersion(target)
+	if err != nil {
+		return false, fmt.Errorf("invalid target version: %w", err)
+	}
+	// A bare major version such as "1" names no minor line
+	if !strings.Contains(target, ".") {
+		return false, fmt.Errorf("invalid target version: %q has no minor version", target)
+	}
+
+	return major == v.Major && minor == v.Minor, nil
+}
+
+// parseGoMajorMinor extracts the major and minor numbers from a Go version
+// string such as "go1.21.0" or "go1.22rc1"
+func parseGoMajorMinor(version string) (major, minor int, err error) {
 	version = strings.TrimPrefix(version, "go")
 
 	parts := strings.Split(version, ".")
@@ -118,12 +269,17 @@ func GetGoMajorMinor() (major, minor int, err error) {
 		return 0, 0, fmt.Errorf("invalid version format: %s", version)
 	}
 
-	major, err = strconv.Atoi(parts[0])
+	major, err = parseVersionNumber(parts[0])
 	if err != nil {
 		return 0, 0, fmt.Errorf("invalid major version: %s", parts[0])
 	}
 
-	minor, err = strconv.Atoi(parts[1])
+	// Drop a pre-release suffix such as "22rc1" -> "22"
+	minorPart := parts[1]
+	if i := strings.IndexFunc(minorPart, func(r rune) bool { return r < '0' || r > '9' }); i > 0 {
+		minorPart = minorPart[:i]
+	}
+	minor, err = parseVersionNumber(minorPart)
 	if err != nil {
 		return 0, 0, fmt.Errorf("invalid minor version: %s", parts[1])
 	}
@@ -131,6 +287,15 @@ func GetGoMajorMinor() (major, minor int, err error) {
 	return major, minor, nil
 }
 
+// parseVersionNumber parses a non-negative decimal version component,
+// rejecting signs and other characters strconv.Atoi would accept
+func parseVersionNumber(s string) (int, error) {
+	if s == "" || strings.Trim(s, "0123456789") != "" {
+		return 0, fmt.Errorf("invalid version number: %q", s)
+	}
+	return strconv.Atoi(s)
+}
+
 // Environment represents different deployment environments
 type Environment string
 
@@ -146,11 +311,41 @@ type Condition struct {
 	Name        string
 	Description string
 	Check       func() (bool, error)
+
+	// DetailedCheck, if set, is used instead of Check and additionally
+	// returns a human-readable explanation of the outcome
+	DetailedCheck func() (bool, string, error)
+
+	// MeasuredCheck, if set and DetailedCheck is not, is used instead of
+	// Check and additionally returns the values the check measured
+	MeasuredCheck func() (bool, map[string]any, error)
+
+	// ContextCheck, if set, is used instead of the other checks and
+	// receives the context of the test run, which carries the run's
+	// Memoize cache. It may also return an explanation of the outcome
+	ContextCheck func(ctx context.Context) (bool, string, error)
+
+	// ErrorIsPass marks an advisory condition: if Check returns an error,
+	// the error is recorded but the condition still counts as passing.
+	// A clean (false, nil) result is still a failure
+	ErrorIsPass bool
+
+	// Labels are free-form metadata, e.g. {"stage": "deploy"}, used to
+	// select subsets of a set with FilterByLabel
+	Labels map[string]string
+
+	// ExitCode, if non-zero, is the process exit code to use when this
+	// condition fails (see TestResults.ExitCode)
+	ExitCode int
+
+	// group is the nested set of a condition added with AddGroup
+	group *ConditionSet
 }
 
 // ConditionSet is a collection of conditions to test
 type ConditionSet struct {
 	conditions []Condition
+	skipped    map[string]bool
 }
 
 // NewConditionSet creates a new condition set
@@ -169,12 +364,142 @@ func (cs *ConditionSet) Add(name, description string, check func() (bool, error)
 	})
 }
 
+// AddDetailed adds a condition whose check also explains its outcome,
+// e.g. "found 1 CPU, need 2". The explanation is recorded in
+// TestResult.Detail even when the check fails without an error
+func (cs *ConditionSet) AddDetailed(name, description string, check func() (bool, string, error)) {
+	cs.conditions = append(cs.conditions, newDetailedCondition(name, description, check))
+}
+
+// newDetailedCondition builds a condition from a detailed check. Check is
+// also set, discarding the detail, so the condition can be run directly
+func newDetailedCondition(name, description string, check func() (bool, string, error)) Condition {
+	return Condition{
+		Name:        name,
+		Description: description,
+		Check: func() (bool, error) {
+			passed, _, err := check()
+			return passed, err
+		},
+		DetailedCheck: check,
+	}
+}
+
+// AddContext adds a condition whose check receives the context of the
+// test run. Pass it on to probes wrapped with Memoize, including from
+// goroutines the check starts, so they share the run's cache
+func (cs *ConditionSet) AddContext(name, description string, check func(ctx context.Context) (bool, error)) {
+	cs.conditions = append(cs.conditions, newContextCondition(name, description, func(ctx context.Context) (bool, string, error) {
+		passed, err := check(ctx)
+		return passed, "", err
+	}))
+}
+
+// newContextCondition builds a condition from a context check. Check and
+// DetailedCheck are also set, running outside of any test run, so the
+// condition can be run directly
+func newContextCondition(name, description string, check func(ctx context.Context) (bool, string, error)) Condition {
+	cond := newDetailedCondition(name, description, func() (bool, string, error) {
+		return check(context.Background())
+	})
+	cond.ContextCheck = check
+	return cond
+}
+
+// AddLazy adds a condition whose check is built by factory at test time,
+// right before it runs. The factory is invoked on every run that
+// evaluates the condition, and never for runs that skip or do not select
+// it (see SkipChecks and TestOnly), which defers acquiring expensive
+// resources until they are needed
+func (cs *ConditionSet) AddLazy(name, description string, factory func() func() (bool, error)) {
+	cs.Add(name, description, func() (bool, error) {
+		check := factory()
+		if check == nil {
+			return false, errors.New("lazy condition factory returned a nil check")
+		}
+		return check()
+	})
+}
+
+// AddAll adds the given conditions to the set in order. Like Add, it
+// does not check names: a condition with an existing name is appended
+// as a separate entry
+func (cs *ConditionSet) AddAll(conditions ...Condition) {
+	cs.conditions = append(cs.conditions, conditions...)
+}
+
+// AddConditionSet adds all conditions of other to the set, with the same
+// collision behavior as AddAll. other is not modified
+func (cs *ConditionSet) AddConditionSet(other *ConditionSet) {
+	cs.AddAll(other.conditions...)
+}
+
+// Get returns the condition registered under name. Since names are not
+// deduplicated (see AddAll), it returns the first condition added with
+// that name
+func (cs *ConditionSet) Get(name string) (Condition, bool) {
+	for _, cond := range cs.conditions {
+		if cond.Name == name {
+			return cond, true
+		}
+	}
+	return Condition{}, false
+}
+
+// Len returns the number of conditions in the set
+func (cs *ConditionSet) Len() int {
+	return len(cs.conditions)
+}
+
+// AddAdvisory adds a condition whose errors do not fail the set
+// (see Condition.ErrorIsPass)
+func (cs *ConditionSet) AddAdvisory(name, description string, check func() (bool, error)) {
+	cs.conditions = append(cs.conditions, Condition{
+		Name:        name,
+		Description: description,
+		Check:       check,
+		ErrorIsPass: true,
+	})
+}
+
 // TestResult represents the result of testing a condition
 type TestResult struct {
 	Name        string
 	Description string
 	Passed      bool
+	Detail      string
 	Error       error
+	ErrorIsPass bool
+
+	// Skipped is set when the check was not run (see SkipChecks)
+	Skipped    bool
+	SkipReason string
+
+	// StartedAt and Duration record when the check ran and how long it
+	// took. They are zero for skipped conditions
+	StartedAt time.Time
+	Duration  time.Duration
+
+	// ExitCode is copied from the condition
+	ExitCode int
+
+	// Metrics holds the values measured by the check (see AddMeasured)
+	Metrics map[string]any
+}
+
+// OK reports whether the result counts as passing: the check passed
+// without error, it errored but the condition is advisory, or it was
+// skipped. A panic (see PanicError) is a bug in the check and always
+// fails, even for advisory conditions
+func (r TestResult) OK() bool {
+	if r.Skipped {
+		return true
+	}
+	if r.Error != nil {
+		var pe *PanicError
+		return r.ErrorIsPass && !errors.As(r.Error, &pe)
+	}
+	return r.Passed
 }
 
 // TestResults represents a collection of test results
@@ -183,24 +508,140 @@ type TestResults []TestResult
 // TestAll tests all conditions in the set
 func (cs *ConditionSet) TestAll() TestResults {
 	results := make(TestResults, 0, len(cs.conditions))
+	skips := cs.skipReasons()
+	ctx := beginRun()
 
 	for _, cond := range cs.conditions {
-		passed, err := cond.Check()
-		results = append(results, TestResult{
-			Name:        cond.Name,
-			Description: cond.Description,
-			Passed:      passed,
-			Error:       err,
-		})
+		results = append(results, evaluateUnlessSkipped(ctx, cond, skips))
 	}
 
 	return results
 }
 
-// AllPassed returns true if all conditions passed
+// ErrNotSupported is returned by checks that are not available on the
+// current platform
+var ErrNotSupported = errors.New("not supported on this platform")
+
+// ErrUnknownCondition is recorded for names that match no condition
+var ErrUnknownCondition = errors.New("unknown condition")
+
+// TestOnly tests only the conditions with the given names, in the order
+// they were added to the set. Every condition sharing a requested name is
+// run. Names that match no condition produce a failed result whose error
+// wraps ErrUnknownCondition, appended after the others
+func (cs *ConditionSet) TestOnly(names ...string) TestResults {
+	wanted := make(map[string]bool, len(names))
+	for _, name := range names {
+		wanted[name] = true
+	}
+
+	results := make(TestResults, 0, len(names))
+	skips := cs.skipReasons()
+	found := make(map[string]bool, len(names))
+	ctx := beginRun()
+	for _, cond := range cs.conditions {
+		if wanted[cond.Name] {
+			found[cond.Name] = true
+			results = append(results, evaluateUnlessSkipped(ctx, cond, skips))
+		}
+	}
+
+	for _, name := range names {
+		if !found[name] {
+			found[name] = true
+			results = append(results, TestResult{
+				Name:  name,
+				Error: fmt.Errorf("%w: %s", ErrUnknownCondition, name),
+			})
+		}
+	}
+
+	return results
+}
+
+// TestMatching tests only the conditions whose names match the regular
+// expression pattern, in the order they were added. Like go test -run,
+// the pattern is unanchored: use "^db-" to select names starting with
+// "db-". An invalid pattern returns an error; a pattern matching nothing
+// returns no results
+func (cs *ConditionSet) TestMatching(pattern string) (TestResults, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid condition pattern: %w", err)
+	}
+
+	var results TestResults
+	skips := cs.skipReasons()
+	ctx := beginRun()
+	for _, cond := range cs.conditions {
+		if re.MatchString(cond.Name) {
+			results = append(results, evaluateUnlessSkipped(ctx, cond, skips))
+		}
+	}
+	return results, nil
+}
+
+// TestAllChan tests all conditions in a separate goroutine, sending each
+// result on the returned channel as soon as it is available. The channel
+// is closed after the last result. It is buffered for every condition, so
+// the goroutine finishes even if the receiver stops reading early
+func (cs *ConditionSet) TestAllChan() <-chan TestResult {
+	conditions := append([]Condition(nil), cs.conditions...)
+	skips := cs.skipReasons()
+	ch := make(chan TestResult, len(conditions))
+	ctx := beginRun()
+
+	go func() {
+		defer close(ch)
+		for _, cond := range conditions {
+			ch <- evaluateUnlessSkipped(ctx, cond, skips)
+		}
+	}()
+
+	return ch
+}
+
+// evaluate runs the condition's check outside of any test run
+func (cond Condition) evaluate() TestResult {
+	return cond.evaluateIn(context.Background())
+}
+
+// evaluateIn runs the condition's check within the run of ctx and records
+// the outcome. A panic in the check is recovered and recorded as a failed
+// result with a PanicError
+func (cond Condition) evaluateIn(ctx context.Context) (result TestResult) {
+	result = TestResult{
+		Name:        cond.Name,
+		Description: cond.Description,
+		ErrorIsPass: cond.ErrorIsPass,
+		StartedAt:   now(),
+		ExitCode:    cond.ExitCode,
+	}
+	defer func() {
+		if v := recover(); v != nil {
+			result.Passed = false
+			result.Error = newPanicError(v)
+		}
+		result.Duration = now().Sub(result.StartedAt)
+	}()
+
+	switch {
+	case cond.ContextCheck != nil:
+		result.Passed, result.Detail, result.Error = cond.ContextCheck(ctx)
+	case cond.DetailedCheck != nil:
+		result.Passed, result.Detail, result.Error = cond.DetailedCheck()
+	case cond.MeasuredCheck != nil:
+		result.Passed, result.Metrics, result.Error = cond.MeasuredCheck()
+	default:
+		result.Passed, result.Error = cond.Check()
+	}
+	return result
+}
+
+// AllPassed returns true if all conditions passed (see TestResult.OK)
 func (results TestResults) AllPassed() bool {
 	for _, r := range results {
-		if !r.Passed || r.Error != nil {
+		if !r.OK() {
 			return false
 		}
 	}
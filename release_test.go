@@ -1,7 +1,10 @@
 package release
 
 import (
+	"errors"
+	"fmt"
 	"runtime"
+	"strings"
 	"testing"
 )
 
@@ -40,7 +43,10 @@ func TestCompareGoVersion(t *testing.T) {
 		{"Valid version 1.20", "1.20", false},
 		{"Valid version go1.20", "go1.20", false},
 		{"Valid version 1.21.0", "1.21.0", false},
+		{"Valid version go1.22rc1", "go1.22rc1", false},
 		{"Invalid version", "invalid", true},
+		{"Empty version", "", true},
+		{"Garbage version", "go1.2.3.4.5", true},
 	}
 
 	for _, tt := range tests {
@@ -57,6 +63,41 @@ func TestCompareGoVersion(t *testing.T) {
 	}
 }
 
+func TestOrdering(t *testing.T) {
+	tests := []struct {
+		current string
+		target  string
+		want    Ordering
+	}{
+		{"go1.21.0", "1.20", Greater},
+		{"go1.21.0", "1.21.0", Equal},
+		{"go1.21.0", "1.22", Less},
+		{"go1.22rc1", "1.22.0", Less},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.current+"/"+tt.target, func(t *testing.T) {
+			got, err := compareVersions(tt.current, tt.target)
+			if err != nil {
+				t.Fatalf("compareVersions() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("compareVersions(%s, %s) = %v, want %v", tt.current, tt.target, got, tt.want)
+			}
+		})
+	}
+
+	if !Less.IsLess() || Less.IsEqual() || Less.IsGreater() {
+		t.Error("Less helpers are inconsistent")
+	}
+	if !Equal.IsEqual() || !Greater.IsGreater() {
+		t.Error("Equal/Greater helpers are inconsistent")
+	}
+	if Greater.String() != "greater" || Ordering(5).String() != "Ordering(5)" {
+		t.Errorf("Unexpected String(): %s, %s", Greater, Ordering(5))
+	}
+}
+
 func TestIsGoVersionAtLeast(t *testing.T) {
 	// Test with a very old version
 	result, err := IsGoVersionAtLeast("1.10")
@@ -77,6 +118,76 @@ func TestIsGoVersionAtLeast(t *testing.T) {
 	}
 }
 
+func TestIsGoVersionAtMost(t *testing.T) {
+	// Test with a very old ceiling (should fail)
+	result, err := IsGoVersionAtMost("go1.10")
+	if err != nil {
+		t.Errorf("IsGoVersionAtMost() error = %v", err)
+	}
+	if result {
+		t.Error("Current Go version should not be at most 1.10")
+	}
+
+	// Test with a future ceiling
+	result, err = IsGoVersionAtMost("v99.99")
+	if err != nil {
+		t.Errorf("IsGoVersionAtMost() error = %v", err)
+	}
+	if !result {
+		t.Error("Current Go version should be at most 99.99")
+	}
+
+	// The current version is its own ceiling
+	if result, err := IsGoVersionAtMost(runtime.Version()); err == nil && !result {
+		t.Errorf("Current Go version should be at most %s", runtime.Version())
+	}
+
+	if _, err := IsGoVersionAtMost("not-a-version"); err == nil || !strings.HasPrefix(err.Error(), "invalid target version: ") || errors.Unwrap(err) == nil {
+		t.Errorf("IsGoVersionAtMost() error = %v, want the wrapped CompareGoVersion error", err)
+	}
+}
+
+func TestGoVersionBetween(t *testing.T) {
+	tests := []struct {
+		current  string
+		min, max string
+		want     bool
+		wantErr  bool
+	}{
+		{"go1.20.3", "1.19", "1.22", true, false},
+		{"go1.19", "1.19", "1.22", true, false},
+		{"go1.19.0", "go1.19", "1.22", true, false},
+		{"go1.22.0", "1.19", "1.22", true, false},
+		{"go1.22.1", "1.19", "1.22", false, false},
+		{"go1.22.1", "1.19", "1.22.1", true, false},
+		{"go1.18.10", "1.19", "1.22", false, false},
+		{"go1.19rc1", "1.19", "1.22", false, false},
+		{"go1.21.0", "1.21", "1.21", true, false},
+		{"go1.20.3", "1.22", "1.19", false, true},
+		{"go1.20.3", "invalid", "1.22", false, true},
+		{"go1.20.3", "1.19", "", false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.current+"/"+tt.min+"-"+tt.max, func(t *testing.T) {
+			got, err := goVersionBetween(tt.current, tt.min, tt.max)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("goVersionBetween() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("goVersionBetween(%s, %s, %s) = %v, want %v", tt.current, tt.min, tt.max, got, tt.want)
+			}
+		})
+	}
+
+	if _, err := IsGoVersionBetween("1.22", "1.19"); err == nil || !strings.Contains(err.Error(), "invalid range") {
+		t.Errorf("IsGoVersionBetween() with min > max error = %v, want invalid range", err)
+	}
+	if ok, err := IsGoVersionBetween("1.10", "99.99"); !ok || err != nil {
+		t.Errorf("IsGoVersionBetween(1.10, 99.99) = %v, %v; want true", ok, err)
+	}
+}
+
 func TestGetGoMajorMinor(t *testing.T) {
 	major, minor, err := GetGoMajorMinor()
 	if err != nil {
@@ -94,6 +205,107 @@ func TestGetGoMajorMinor(t *testing.T) {
 	t.Logf("Go version: %d.%d", major, minor)
 }
 
+func TestGoVersionParts(t *testing.T) {
+	tests := []struct {
+		version             string
+		major, minor, patch int
+		wantErr             bool
+	}{
+		{"go1.21.4", 1, 21, 4, false},
+		{"go1.22", 1, 22, 0, false},
+		{"go1.22rc1", 1, 22, 0, false},
+		{"go1.21beta2", 1, 21, 0, false},
+		{"go1.21.0 X:boringcrypto", 1, 21, 0, false},
+		{"go1.x.1", 0, 0, 0, true},
+		{"devel go1.23-abc123", 0, 0, 0, true},
+	}
+	for _, tt := range tests {
+		major, minor, patch, err := goVersionParts(tt.version)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("goVersionParts(%q) error = %v, wantErr %v", tt.version, err, tt.wantErr)
+			continue
+		}
+		if major != tt.major || minor != tt.minor || patch != tt.patch {
+			t.Errorf("goVersionParts(%q) = %d.%d.%d, want %d.%d.%d", tt.version, major, minor, patch, tt.major, tt.minor, tt.patch)
+		}
+	}
+
+	major, minor, _, err := GetGoVersionParts()
+	if wantMajor, wantMinor, _ := GetGoMajorMinor(); err != nil || major != wantMajor || minor != wantMinor {
+		t.Errorf("GetGoVersionParts() = %d.%d, %v; want %d.%d", major, minor, err, wantMajor, wantMinor)
+	}
+}
+
+func TestCompareGoVersionParts(t *testing.T) {
+	tests := []struct {
+		current             string
+		major, minor, patch int
+		want                Ordering
+		wantErr             bool
+	}{
+		{"go1.21.5", 1, 21, 5, Equal, false},
+		{"go1.21.5", 1, 21, 6, Less, false},
+		{"go1.21.5", 1, 20, 9, Greater, false},
+		{"go1.21.5", 2, 0, 0, Less, false},
+		{"go1.20", 1, 20, 0, Equal, false},
+		{"go1.22rc1", 1, 22, 0, Less, false},
+		{"go1.22rc1", 1, 21, 9, Greater, false},
+		{"go1.21.0 X:boringcrypto", 1, 21, 0, Equal, false},
+		{"devel go1.23-abc123", 1, 23, 0, 0, true},
+		{"go1.21.5", 1, -1, 0, 0, true},
+	}
+	for _, tt := range tests {
+		got, err := compareGoVersionParts(tt.current, tt.major, tt.minor, tt.patch)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("compareGoVersionParts(%q, %d, %d, %d) error = %v, wantErr %v", tt.current, tt.major, tt.minor, tt.patch, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("compareGoVersionParts(%q, %d, %d, %d) = %v, want %v", tt.current, tt.major, tt.minor, tt.patch, got, tt.want)
+		}
+	}
+
+	major, minor, _ := GetGoMajorMinor()
+	if got, err := CompareGoVersionParts(major, minor+1, 0); err != nil || got != Less {
+		t.Errorf("CompareGoVersionParts(%d, %d, 0) = %v, %v; want less", major, minor+1, got, err)
+	}
+}
+
+func TestSameMinorLine(t *testing.T) {
+	tests := []struct {
+		current string
+		target  string
+		want    bool
+		wantErr bool
+	}{
+		{"go1.21.5", "1.21", true, false},
+		{"go1.21.5", "go1.21.0", true, false},
+		{"go1.21.5", "v1.21.9", true, false},
+		{"go1.22rc1", "1.22.3", true, false},
+		{"go1.21.5", "1.22", false, false},
+		{"go1.21.5", "2.21", false, false},
+		{"go1.21.5", "invalid", false, true},
+		{"go1.21.5", "1", false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.current+"/"+tt.target, func(t *testing.T) {
+			got, err := sameMinorLine(tt.current, tt.target)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("sameMinorLine() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("sameMinorLine(%s, %s) = %v, want %v", tt.current, tt.target, got, tt.want)
+			}
+		})
+	}
+
+	major, minor, _ := GetGoMajorMinor()
+	if ok, err := SameMinorLine(fmt.Sprintf("%d.%d.99", major, minor)); !ok || err != nil {
+		t.Errorf("SameMinorLine for the running minor = %v, %v; want true, nil", ok, err)
+	}
+}
+
 func TestIsPlatform(t *testing.T) {
 	// Test current platform
 	if !IsPlatform(runtime.GOOS, runtime.GOARCH) {
@@ -163,22 +375,272 @@ func TestConditionSet(t *testing.T) {
 	}
 }
 
-func TestNormalizeGoVersion(t *testing.T) {
+func TestAddDetailed(t *testing.T) {
+	cs := NewConditionSet()
+	cs.AddDetailed("cpus", "At least 2 CPUs", func() (bool, string, error) {
+		return false, "found 1 CPU, need 2", nil
+	})
+
+	results := cs.TestAll()
+	if len(results) != 1 {
+		t.Fatalf("Expected 1 result, got %d", len(results))
+	}
+	if results[0].Passed || results[0].Error != nil {
+		t.Errorf("Expected a clean failure, got %+v", results[0])
+	}
+	if results[0].Detail != "found 1 CPU, need 2" {
+		t.Errorf("Detail = %q, want %q", results[0].Detail, "found 1 CPU, need 2")
+	}
+
+	// Detailed conditions can still be run through Check directly
+	cond := newDetailedCondition("direct", "", func() (bool, string, error) {
+		return true, "fine", nil
+	})
+	if ok, err := cond.Check(); !ok || err != nil {
+		t.Errorf("Check() = %v, %v; want true, nil", ok, err)
+	}
+}
+
+func TestAddLazy(t *testing.T) {
+	built := 0
+	cs := NewConditionSet()
+	cs.AddLazy("lazy", "Built on demand", func() func() (bool, error) {
+		built++
+		return func() (bool, error) { return true, nil }
+	})
+	cs.AddLazy("nil-check", "Factory returns nil", func() func() (bool, error) {
+		return nil
+	})
+
+	if built != 0 {
+		t.Fatal("The factory should not run when the condition is added")
+	}
+
+	cs.TestOnly("nil-check")
+	if built != 0 {
+		t.Error("The factory should not run for unselected conditions")
+	}
+
+	results := cs.TestAll()
+	if built != 1 || !results[0].Passed {
+		t.Errorf("Expected one factory call and a pass, got %d calls and %+v", built, results[0])
+	}
+	if results[1].Error == nil {
+		t.Error("A nil check from the factory should produce an error")
+	}
+}
+
+func TestAddAll(t *testing.T) {
+	cs := NewConditionSet()
+	cs.AddAll(NotGoRunCondition(), GCEnabledCondition())
+
+	other := NewConditionSet()
+	other.Add("extra", "Extra check", func() (bool, error) {
+		return true, nil
+	})
+	other.AddAll(GCEnabledCondition())
+	cs.AddConditionSet(other)
+
+	results := cs.TestAll()
+	names := make([]string, 0, len(results))
+	for _, r := range results {
+		names = append(names, r.Name)
+	}
+
+	// Duplicate names are kept as separate entries, as with Add
+	want := "not-go-run,gc-enabled,extra,gc-enabled"
+	if got := strings.Join(names, ","); got != want {
+		t.Errorf("Conditions = %s, want %s", got, want)
+	}
+	if len(other.TestAll()) != 2 {
+		t.Error("AddConditionSet should not modify the source set")
+	}
+}
+
+func TestGet(t *testing.T) {
+	cs := NewConditionSet()
+	cs.Add("dup", "first", func() (bool, error) { return true, nil })
+	cs.Add("dup", "second", func() (bool, error) { return false, nil })
+	cs.AddAll(GCEnabledCondition())
+
+	cond, ok := cs.Get("dup")
+	if !ok || cond.Description != "first" {
+		t.Errorf("Get(dup) = %+v, %v; want the first condition added", cond, ok)
+	}
+	if cond, ok := cs.Get("gc-enabled"); !ok || cond.DetailedCheck == nil {
+		t.Errorf("Get(gc-enabled) = %+v, %v", cond, ok)
+	}
+	if _, ok := cs.Get("missing"); ok {
+		t.Error("Get should return false for an unknown name")
+	}
+}
+
+func TestAddAdvisory(t *testing.T) {
+	cs := NewConditionSet()
+	cs.Add("required", "Always passes", func() (bool, error) {
+		return true, nil
+	})
+	cs.AddAdvisory("advisory", "Cannot read /proc", func() (bool, error) {
+		return false, errors.New("permission denied")
+	})
+
+	results := cs.TestAll()
+	if !results.AllPassed() {
+		t.Error("An errored advisory condition should not fail the set")
+	}
+	if results[1].Error == nil {
+		t.Error("The advisory error should still be recorded")
+	}
+	if !results[1].ErrorIsPass {
+		t.Error("ErrorIsPass should be carried into the result")
+	}
+
+	// A clean failure is still a failure, even for advisory conditions
+	cs.AddAdvisory("advisory-fail", "Fails cleanly", func() (bool, error) {
+		return false, nil
+	})
+	if cs.TestAll().AllPassed() {
+		t.Error("A clean advisory failure should fail the set")
+	}
+}
+
+func TestTestResultOK(t *testing.T) {
+	err := errors.New("boom")
+	tests := []struct {
+		name   string
+		result TestResult
+		want   bool
+	}{
+		{"passed", TestResult{Passed: true}, true},
+		{"failed", TestResult{Passed: false}, false},
+		{"errored", TestResult{Passed: true, Error: err}, false},
+		{"errored advisory", TestResult{Error: err, ErrorIsPass: true}, true},
+		{"failed advisory", TestResult{ErrorIsPass: true}, false},
+		{"panicked advisory", TestResult{Error: &PanicError{Value: "boom"}, ErrorIsPass: true}, false},
+		{"wrapped panic advisory", TestResult{Error: fmt.Errorf("check: %w", &PanicError{Value: "boom"}), ErrorIsPass: true}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.result.OK(); got != tt.want {
+				t.Errorf("OK() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTestAllChan(t *testing.T) {
+	cs := NewConditionSet()
+	for _, name := range []string{"first", "second", "third"} {
+		name := name
+		cs.Add(name, "Ordered check", func() (bool, error) {
+			return name != "second", nil
+		})
+	}
+
+	var names []string
+	var failed int
+	for result := range cs.TestAllChan() {
+		names = append(names, result.Name)
+		if !result.Passed {
+			failed++
+		}
+	}
+
+	if len(names) != 3 || names[0] != "first" || names[2] != "third" {
+		t.Errorf("Results received out of order: %v", names)
+	}
+	if failed != 1 {
+		t.Errorf("Expected 1 failed result, got %d", failed)
+	}
+}
+
+func TestTestOnly(t *testing.T) {
+	cs := NewConditionSet()
+	ran := map[string]int{}
+	for _, name := range []string{"db", "cache", "dns"} {
+		name := name
+		cs.Add(name, "Check "+name, func() (bool, error) {
+			ran[name]++
+			return true, nil
+		})
+	}
+
+	results := cs.TestOnly("dns", "db", "queue")
+	if len(results) != 3 {
+		t.Fatalf("Expected 3 results, got %d", len(results))
+	}
+	if results[0].Name != "db" || results[1].Name != "dns" {
+		t.Errorf("Results should follow set order, got %s, %s", results[0].Name, results[1].Name)
+	}
+	if ran["cache"] != 0 || ran["db"] != 1 || ran["dns"] != 1 {
+		t.Errorf("Unexpected runs: %v", ran)
+	}
+	if results[2].Name != "queue" || !errors.Is(results[2].Error, ErrUnknownCondition) {
+		t.Errorf("Unknown name should produce an errored result, got %+v", results[2])
+	}
+}
+
+func TestTestMatching(t *testing.T) {
+	cs := NewConditionSet()
+	ran := map[string]int{}
+	for _, name := range []string{"db-primary", "cache", "db-replica", "mydb"} {
+		name := name
+		cs.Add(name, "Check "+name, func() (bool, error) {
+			ran[name]++
+			return true, nil
+		})
+	}
+
+	results, err := cs.TestMatching("^db-")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(results) != 2 || results[0].Name != "db-primary" || results[1].Name != "db-replica" {
+		t.Errorf("Expected db-primary and db-replica in set order, got %+v", results)
+	}
+	if ran["cache"] != 0 || ran["mydb"] != 0 {
+		t.Errorf("Unexpected runs: %v", ran)
+	}
+
+	results, err = cs.TestMatching("queue")
+	if err != nil || len(results) != 0 {
+		t.Errorf("Expected no results and no error, got %v, %v", results, err)
+	}
+
+	if _, err := cs.TestMatching("db-("); err == nil {
+		t.Error("Expected an error for an invalid pattern")
+	}
+}
+
+func TestParseGoMajorMinor(t *testing.T) {
 	tests := []struct {
-		input    string
-		expected string
+		input   string
+		major   int
+		minor   int
+		wantErr bool
 	}{
-		{"go1.21.0", "v1.21.0"},
-		{"1.21.0", "v1.21.0"},
-		{"v1.21.0", "v1.21.0"},
-		{"go1.20", "v1.20"},
+		{"go1.21.0", 1, 21, false},
+		{"go1.22", 1, 22, false},
+		{"go1.22rc1", 1, 22, false},
+		{"go1.21beta2", 1, 21, false},
+		{"go1", 0, 0, true},
+		{"go1.", 0, 0, true},
+		{"go1.-2", 0, 0, true},
+		{"go+1.2", 0, 0, true},
+		{"go1.rc1", 0, 0, true},
+		{"devel +abc", 0, 0, true},
+		{"", 0, 0, true},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.input, func(t *testing.T) {
-			result := normalizeGoVersion(tt.input)
-			if result != tt.expected {
-				t.Errorf("normalizeGoVersion(%s) = %s, want %s", tt.input, result, tt.expected)
+			major, minor, err := parseGoMajorMinor(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseGoMajorMinor(%s) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if major != tt.major || minor != tt.minor {
+				t.Errorf("parseGoMajorMinor(%s) = %d.%d, want %d.%d", tt.input, major, minor, tt.major, tt.minor)
 			}
 		})
 	}
@@ -201,3 +663,21 @@ func BenchmarkCompareGoVersion(b *testing.B) {
 		CompareGoVersion("1.20")
 	}
 }
+
+func FuzzCompareGoVersion(f *testing.F) {
+	for _, seed := range []string{"1.20", "go1.21.0", "v1.21", "go1.22rc1", "go1.21beta2", "", "go", "v", "1..2", "rc", "1.rc1"} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, version string) {
+		cmp, err := CompareGoVersion(version)
+		if err == nil && (cmp < -1 || cmp > 1) {
+			t.Errorf("CompareGoVersion(%q) = %d, want -1, 0 or 1", version, cmp)
+		}
+
+		major, minor, err := parseGoMajorMinor(version)
+		if err == nil && (major < 0 || minor < 0) {
+			t.Errorf("parseGoMajorMinor(%q) = %d.%d, want non-negative", version, major, minor)
+		}
+	})
+}
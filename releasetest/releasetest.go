@@ -0,0 +1,56 @@
+// Package releasetest provides assertions for tests of release
+// conditions, keeping the testing package out of the main package
+package releasetest
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	release "github.com/parthban-db/test-go-release"
+)
+
+// AssertReady runs every condition of cs and fails the test, with the
+// full results table, unless all of them pass (see TestResults.AllPassed).
+// It reports whether the set was ready
+func AssertReady(t testing.TB, cs *release.ConditionSet) bool {
+	t.Helper()
+	results := cs.TestAll()
+	if results.AllPassed() {
+		return true
+	}
+	var report strings.Builder
+	results.WriteTable(&report, release.ReportOptions{Symbols: release.ASCIISymbols})
+	t.Errorf("condition set is not ready:\n%s", report.String())
+	return false
+}
+
+// AssertConditionPasses runs only the named condition of cs and fails the
+// test unless it passes (see TestResult.OK), or if cs has no such
+// condition. It reports whether the condition passed
+func AssertConditionPasses(t testing.TB, cs *release.ConditionSet, name string) bool {
+	t.Helper()
+	r := cs.TestOnly(name)[0]
+	switch {
+	case errors.Is(r.Error, release.ErrUnknownCondition):
+		t.Errorf("condition %q not found", name)
+	case !r.OK():
+		t.Errorf("condition %q failed: %s", name, explain(r))
+	default:
+		return true
+	}
+	return false
+}
+
+// explain summarizes why a result did not pass
+func explain(r release.TestResult) string {
+	switch {
+	case r.Error != nil && r.Detail != "":
+		return r.Detail + "; error: " + r.Error.Error()
+	case r.Error != nil:
+		return "error: " + r.Error.Error()
+	case r.Detail != "":
+		return r.Detail
+	}
+	return "condition not met: " + r.Description
+}
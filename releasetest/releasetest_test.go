@@ -0,0 +1,72 @@
+package releasetest
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	release "github.com/parthban-db/test-go-release"
+)
+
+// recorder captures the failures reported through testing.TB
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func newSet() *release.ConditionSet {
+	cs := release.NewConditionSet()
+	cs.Add("ok", "Always passes", func() (bool, error) { return true, nil })
+	cs.AddDetailed("disk", "Enough disk", func() (bool, string, error) { return false, "10 MB free, need 1 GB", nil })
+	cs.Add("dns", "Resolves", func() (bool, error) { return false, errors.New("no such host") })
+	cs.AddAdvisory("cache", "Cache reachable", func() (bool, error) { return false, errors.New("timeout") })
+	return cs
+}
+
+func TestAssertReady(t *testing.T) {
+	rec := &recorder{}
+	if AssertReady(rec, newSet()) {
+		t.Error("AssertReady should report a failing set as not ready")
+	}
+	if len(rec.errors) != 1 || !strings.Contains(rec.errors[0], "10 MB free, need 1 GB") || !strings.Contains(rec.errors[0], "2/4 conditions passed") {
+		t.Errorf("Expected one failure with the results table, got %q", rec.errors)
+	}
+
+	rec = &recorder{}
+	cs := release.NewConditionSet()
+	cs.Add("ok", "Always passes", func() (bool, error) { return true, nil })
+	if !AssertReady(rec, cs) || len(rec.errors) != 0 {
+		t.Errorf("AssertReady failed a ready set: %q", rec.errors)
+	}
+}
+
+func TestAssertConditionPasses(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    bool
+		wantErr string
+	}{
+		{"ok", true, ""},
+		{"cache", true, ""},
+		{"disk", false, `condition "disk" failed: 10 MB free, need 1 GB`},
+		{"dns", false, `condition "dns" failed: error: no such host`},
+		{"queue", false, `condition "queue" not found`},
+	}
+	for _, tt := range tests {
+		rec := &recorder{}
+		got := AssertConditionPasses(rec, newSet(), tt.name)
+		if got != tt.want {
+			t.Errorf("AssertConditionPasses(%q) = %v, want %v", tt.name, got, tt.want)
+		}
+		if tt.wantErr == "" && len(rec.errors) != 0 || tt.wantErr != "" && (len(rec.errors) != 1 || rec.errors[0] != tt.wantErr) {
+			t.Errorf("AssertConditionPasses(%q) reported %q, want %q", tt.name, rec.errors, tt.wantErr)
+		}
+	}
+}
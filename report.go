@@ -0,0 +1,262 @@
+package release
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// Symbols are the status markers used by the text reporters
+type Symbols struct {
+	Pass  string
+	Fail  string
+	Error string
+	Skip  string
+}
+
+var (
+	// EmojiSymbols are the Unicode markers, suited to interactive terminals
+	EmojiSymbols = Symbols{Pass: "✓", Fail: "✗", Error: "⚠", Skip: "-"}
+
+	// ASCIISymbols are plain markers for log systems that mangle UTF-8
+	ASCIISymbols = Symbols{Pass: "[PASS]", Fail: "[FAIL]", Error: "[ERROR]", Skip: "[SKIP]"}
+)
+
+// DefaultSymbols returns EmojiSymbols when w is a terminal and
+// ASCIISymbols otherwise
+func DefaultSymbols(w io.Writer) Symbols {
+	if isTerminal(w) {
+		return EmojiSymbols
+	}
+	return ASCIISymbols
+}
+
+// isTerminal reports whether w is a character device such as a TTY
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	stat, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return stat.Mode()&os.ModeCharDevice != 0
+}
+
+// ReportOptions configures the text reporters
+type ReportOptions struct {
+	// Symbols overrides the status markers. If zero, DefaultSymbols is
+	// used for the destination writer
+	Symbols Symbols
+
+	// Timings adds when the run started and how long it took to the
+	// summary line. It is off by default so that runs with the same
+	// outcomes produce identical reports, e.g. for golden files or diffs
+	Timings bool
+
+	// LocalTime renders timestamps in the host's local timezone instead
+	// of UTC, for interactive use. Serialized formats (JSON) always use UTC
+	LocalTime bool
+}
+
+// formatTime renders t as RFC 3339, in UTC unless LocalTime is set
+func (opts ReportOptions) formatTime(t time.Time) string {
+	if opts.LocalTime {
+		return t.Local().Format(time.RFC3339)
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// summaryLine returns the closing line of the text reports, including when
+// the run started and how long it took if Timings is set and the results
+// have timings
+func (results TestResults) summaryLine(opts ReportOptions) string {
+	line := fmt.Sprintf("%d/%d conditions passed", results.passedCount(), len(results))
+	if !opts.Timings {
+		return line
+	}
+	start, end := results.timeSpan()
+	if start.IsZero() {
+		return line
+	}
+	return fmt.Sprintf("%s (started %s, took %s)", line, opts.formatTime(start), end.Sub(start))
+}
+
+// timeSpan returns the earliest start and latest end of the results that
+// have timings
+func (results TestResults) timeSpan() (start, end time.Time) {
+	for _, r := range results {
+		if r.StartedAt.IsZero() {
+			continue
+		}
+		if start.IsZero() || r.StartedAt.Before(start) {
+			start = r.StartedAt
+		}
+		if e := r.StartedAt.Add(r.Duration); e.After(end) {
+			end = e
+		}
+	}
+	return start, end
+}
+
+// symbol returns the marker for a result
+func (s Symbols) symbol(r TestResult) string {
+	switch {
+	case r.Skipped:
+		return s.Skip
+	case r.Error != nil:
+		return s.Error
+	case r.Passed:
+		return s.Pass
+	default:
+		return s.Fail
+	}
+}
+
+// symbolsFor resolves the symbols to use for w
+func (opts ReportOptions) symbolsFor(w io.Writer) Symbols {
+	if opts.Symbols == (Symbols{}) {
+		return DefaultSymbols(w)
+	}
+	return opts.Symbols
+}
+
+// passedCount returns the number of results that count as passing
+func (results TestResults) passedCount() int {
+	passed := 0
+	for _, r := range results {
+		if r.OK() {
+			passed++
+		}
+	}
+	return passed
+}
+
+// writeSection writes a titled list of results, if there are any, as
+// "title:" followed by one "  - name (reason)" line per result
+func writeSection(b *strings.Builder, title string, results TestResults) {
+	if len(results) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "%s:\n", title)
+	for _, r := range results {
+		fmt.Fprintf(b, "  - %s\n", describeFailure(r))
+	}
+}
+
+// WriteTable writes the results as an aligned text table, the failures of
+// required conditions and the warnings of advisory ones as separate
+// sections, and a summary line
+func (results TestResults) WriteTable(w io.Writer, opts ReportOptions) error {
+	symbols := opts.symbolsFor(w)
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	for _, r := range results {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", symbols.symbol(r), r.Name, r.Description)
+		if r.Skipped {
+			fmt.Fprintf(tw, "\t\t%s\n", r.SkipReason)
+		}
+		if r.Detail != "" {
+			fmt.Fprintf(tw, "\t\t%s\n", r.Detail)
+		}
+		if r.Error != nil {
+			fmt.Fprintf(tw, "\t\terror: %v\n", r.Error)
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	var b strings.Builder
+	writeSection(&b, "Failures", results.FailedResults())
+	writeSection(&b, "Warnings", results.Warnings())
+	fmt.Fprintln(&b, results.summaryLine(opts))
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// jsonReport is the document written by WriteJSON
+type jsonReport struct {
+	SchemaVersion string      `json:"schema_version"`
+	AllPassed     bool        `json:"all_passed"`
+	Total         int         `json:"total"`
+	Passed        int         `json:"passed"`
+	Failures      []string    `json:"failures"`
+	Warnings      []string    `json:"warnings"`
+	Results       TestResults `json:"results"`
+}
+
+// WriteJSON writes the results and the overall verdict as an indented
+// JSON document. The names of failed required conditions and of advisory
+// conditions that errored are listed under "failures" and "warnings"
+func (results TestResults) WriteJSON(w io.Writer) error {
+	if results == nil {
+		results = TestResults{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(jsonReport{
+		SchemaVersion: SchemaVersion,
+		AllPassed:     results.AllPassed(),
+		Total:         len(results),
+		Passed:        results.passedCount(),
+		Failures:      results.FailedResults().names(),
+		Warnings:      results.Warnings().names(),
+		Results:       results,
+	})
+}
+
+// WriteMarkdown writes the results as a Markdown table, the failures and
+// warnings as separate lists and a summary line, e.g. for CI job
+// summaries or PR comments
+func (results TestResults) WriteMarkdown(w io.Writer, opts ReportOptions) error {
+	symbols := opts.symbolsFor(w)
+
+	var b strings.Builder
+	b.WriteString("| Status | Condition | Description | Detail |\n")
+	b.WriteString("|--------|-----------|-------------|--------|\n")
+	for _, r := range results {
+		detail := r.Detail
+		switch {
+		case r.Skipped:
+			detail = r.SkipReason
+		case r.Error != nil && detail != "":
+			detail += "; error: " + r.Error.Error()
+		case r.Error != nil:
+			detail = "error: " + r.Error.Error()
+		}
+		fmt.Fprintf(&b, "| %s | %s | %s | %s |\n",
+			markdownCell(symbols.symbol(r)), markdownCell(r.Name), markdownCell(r.Description), markdownCell(detail))
+	}
+	for _, section := range []struct {
+		title   string
+		results TestResults
+	}{
+		{"Failures", results.FailedResults()},
+		{"Warnings", results.Warnings()},
+	} {
+		if len(section.results) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\n**%s**\n\n", section.title)
+		for _, r := range section.results {
+			fmt.Fprintf(&b, "- %s\n", markdownCell(describeFailure(r)))
+		}
+	}
+	fmt.Fprintf(&b, "\n%s\n", results.summaryLine(opts))
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// markdownCell escapes text for use inside a Markdown table cell
+func markdownCell(s string) string {
+	s = strings.ReplaceAll(s, "|", "\\|")
+	s = strings.ReplaceAll(s, "\r\n", " ")
+	return strings.ReplaceAll(s, "\n", " ")
+}
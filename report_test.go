@@ -0,0 +1,184 @@
+package release
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteTable(t *testing.T) {
+	results := TestResults{
+		{Name: "go-version", Description: "Go 1.20+", Passed: true},
+		{Name: "platform", Description: "Linux only", Passed: false, Detail: "running on plan9"},
+		{Name: "proc", Description: "Read /proc", Error: errors.New("permission denied")},
+	}
+
+	var buf bytes.Buffer
+	if err := results.WriteTable(&buf, ReportOptions{}); err != nil {
+		t.Fatalf("WriteTable() error = %v", err)
+	}
+	out := buf.String()
+
+	// A bytes.Buffer is not a terminal, so ASCII symbols are the default
+	for _, want := range []string{"[PASS]", "[FAIL]", "[ERROR]", "permission denied", "running on plan9", "1/3 conditions passed"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("WriteTable output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "✓") {
+		t.Errorf("WriteTable should not use emoji for non-terminal output:\n%s", out)
+	}
+}
+
+func TestReportWarnings(t *testing.T) {
+	results := TestResults{
+		{Name: "platform", Passed: false, Detail: "running on plan9"},
+		{Name: "cache", ErrorIsPass: true, Error: errors.New("timeout")},
+		{Name: "ok", Passed: true},
+	}
+
+	var buf bytes.Buffer
+	if err := results.WriteTable(&buf, ReportOptions{}); err != nil {
+		t.Fatalf("WriteTable() error = %v", err)
+	}
+	if want := "Failures:\n  - platform (running on plan9)\nWarnings:\n  - cache (timeout)\n"; !strings.Contains(buf.String(), want) {
+		t.Errorf("WriteTable output missing sections %q:\n%s", want, buf.String())
+	}
+
+	buf.Reset()
+	if err := results.WriteMarkdown(&buf, ReportOptions{}); err != nil {
+		t.Fatalf("WriteMarkdown() error = %v", err)
+	}
+	if want := "**Failures**\n\n- platform (running on plan9)\n\n**Warnings**\n\n- cache (timeout)\n"; !strings.Contains(buf.String(), want) {
+		t.Errorf("WriteMarkdown output missing sections %q:\n%s", want, buf.String())
+	}
+
+	buf.Reset()
+	if err := results.WriteJSON(&buf); err != nil {
+		t.Fatalf("WriteJSON() error = %v", err)
+	}
+	var report struct {
+		Failures []string `json:"failures"`
+		Warnings []string `json:"warnings"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatalf("WriteJSON produced invalid JSON: %v", err)
+	}
+	if len(report.Failures) != 1 || report.Failures[0] != "platform" || len(report.Warnings) != 1 || report.Warnings[0] != "cache" {
+		t.Errorf("Unexpected failures and warnings: %+v", report)
+	}
+
+	buf.Reset()
+	if err := (TestResults{{Name: "ok", Passed: true}}).WriteTable(&buf, ReportOptions{}); err != nil || strings.Contains(buf.String(), "Warnings:") || strings.Contains(buf.String(), "Failures:") {
+		t.Errorf("Passing results should have no sections, got %v:\n%s", err, buf.String())
+	}
+}
+
+func TestWriteTableCustomSymbols(t *testing.T) {
+	results := TestResults{{Name: "ok", Passed: true}}
+
+	var buf bytes.Buffer
+	if err := results.WriteTable(&buf, ReportOptions{Symbols: EmojiSymbols}); err != nil {
+		t.Fatalf("WriteTable() error = %v", err)
+	}
+	if !strings.HasPrefix(buf.String(), "✓") {
+		t.Errorf("Expected emoji pass symbol, got:\n%s", buf.String())
+	}
+}
+
+func TestDefaultSymbols(t *testing.T) {
+	if DefaultSymbols(&bytes.Buffer{}) != ASCIISymbols {
+		t.Error("DefaultSymbols should return ASCIISymbols for non-file writers")
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	results := TestResults{
+		{Name: "ok", Passed: true},
+		{Name: "bad", Error: errors.New("boom")},
+	}
+
+	var buf bytes.Buffer
+	if err := results.WriteJSON(&buf); err != nil {
+		t.Fatalf("WriteJSON() error = %v", err)
+	}
+
+	var report struct {
+		AllPassed bool          `json:"all_passed"`
+		Total     int           `json:"total"`
+		Passed    int           `json:"passed"`
+		Results   []interface{} `json:"results"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatalf("WriteJSON produced invalid JSON: %v\n%s", err, buf.String())
+	}
+	if report.AllPassed || report.Total != 2 || report.Passed != 1 || len(report.Results) != 2 {
+		t.Errorf("Unexpected report: %+v", report)
+	}
+
+	buf.Reset()
+	if err := TestResults(nil).WriteJSON(&buf); err != nil || !strings.Contains(buf.String(), `"results": []`) || !strings.Contains(buf.String(), `"warnings": []`) {
+		t.Errorf("Empty results should encode as an empty list, got %v:\n%s", err, buf.String())
+	}
+}
+
+func TestWriteMarkdown(t *testing.T) {
+	results := TestResults{
+		{Name: "ok", Description: "a | b", Passed: true},
+		{Name: "bad", Description: "Fails", Detail: "line1\nline2", Error: errors.New("boom")},
+	}
+
+	var buf bytes.Buffer
+	if err := results.WriteMarkdown(&buf, ReportOptions{Symbols: ASCIISymbols}); err != nil {
+		t.Fatalf("WriteMarkdown() error = %v", err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"| Status | Condition | Description | Detail |",
+		"| [PASS] | ok | a \\| b |  |",
+		"| [ERROR] | bad | Fails | line1 line2; error: boom |",
+		"1/2 conditions passed",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("WriteMarkdown output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestReportTimestamps(t *testing.T) {
+	zone := time.FixedZone("UTC+5", 5*60*60)
+	started := time.Date(2024, 3, 1, 17, 0, 0, 0, zone)
+	results := TestResults{
+		{Name: "a", Passed: true, StartedAt: started, Duration: time.Second},
+		{Name: "b", Passed: true, StartedAt: started.Add(time.Second), Duration: time.Second},
+	}
+
+	var buf bytes.Buffer
+	if err := results.WriteMarkdown(&buf, ReportOptions{}); err != nil {
+		t.Fatalf("WriteMarkdown() error = %v", err)
+	}
+	if strings.Contains(buf.String(), "started") {
+		t.Errorf("WriteMarkdown output should omit timings by default:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	if err := results.WriteMarkdown(&buf, ReportOptions{Timings: true}); err != nil {
+		t.Fatalf("WriteMarkdown() error = %v", err)
+	}
+	if want := "2/2 conditions passed (started 2024-03-01T12:00:00Z, took 2s)"; !strings.Contains(buf.String(), want) {
+		t.Errorf("WriteMarkdown output missing %q:\n%s", want, buf.String())
+	}
+
+	local := started.Local().Format(time.RFC3339)
+	buf.Reset()
+	if err := results.WriteTable(&buf, ReportOptions{Timings: true, LocalTime: true}); err != nil {
+		t.Fatalf("WriteTable() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "started "+local) {
+		t.Errorf("WriteTable output missing local time %q:\n%s", local, buf.String())
+	}
+}
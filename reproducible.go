@@ -0,0 +1,26 @@
+package release
+
+// ReproducibleEqual reports whether info and other describe the same
+// logical build, comparing only fields that are deterministic for a given
+// source tree and toolchain:
+//
+//	GoVersion, Compiler, OS, Arch, CGOEnabled, Trimpath,
+//	ModulePath, ModuleVersion, VCSRevision, VCSModified
+//
+// Host- and time-specific fields (Platform, which is derived from OS/Arch,
+// NumCPU, BuildTime, VCSTime) are ignored. Two nil values are equal
+func (info *BuildInfo) ReproducibleEqual(other *BuildInfo) bool {
+	if info == nil || other == nil {
+		return info == other
+	}
+	return info.GoVersion == other.GoVersion &&
+		info.Compiler == other.Compiler &&
+		info.OS == other.OS &&
+		info.Arch == other.Arch &&
+		info.CGOEnabled == other.CGOEnabled &&
+		info.Trimpath == other.Trimpath &&
+		info.ModulePath == other.ModulePath &&
+		info.ModuleVersion == other.ModuleVersion &&
+		info.VCSRevision == other.VCSRevision &&
+		info.VCSModified == other.VCSModified
+}
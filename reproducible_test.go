@@ -0,0 +1,56 @@
+package release
+
+import "testing"
+
+func TestReproducibleEqual(t *testing.T) {
+	base := func() *BuildInfo {
+		return &BuildInfo{
+			GoVersion:     "go1.21.5",
+			Compiler:      "gc",
+			OS:            "linux",
+			Arch:          "amd64",
+			Platform:      "linux/amd64",
+			Trimpath:      true,
+			ModulePath:    "example.com/app",
+			ModuleVersion: "v1.0.0",
+			VCSRevision:   "abc123",
+			NumCPU:        8,
+			BuildTime:     "2024-01-01T00:00:00Z",
+			VCSTime:       "2024-01-01T00:00:00Z",
+		}
+	}
+
+	other := base()
+	other.NumCPU = 2
+	other.BuildTime = "2024-06-01T00:00:00Z"
+	if !base().ReproducibleEqual(other) {
+		t.Error("Builds differing only in volatile fields should be equal")
+	}
+
+	tests := []struct {
+		name   string
+		modify func(*BuildInfo)
+	}{
+		{"go version", func(b *BuildInfo) { b.GoVersion = "go1.21.6" }},
+		{"arch", func(b *BuildInfo) { b.Arch = "arm64" }},
+		{"trimpath", func(b *BuildInfo) { b.Trimpath = false }},
+		{"module version", func(b *BuildInfo) { b.ModuleVersion = "v1.0.1" }},
+		{"vcs revision", func(b *BuildInfo) { b.VCSRevision = "def456" }},
+		{"vcs modified", func(b *BuildInfo) { b.VCSModified = true }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			other := base()
+			tt.modify(other)
+			if base().ReproducibleEqual(other) {
+				t.Errorf("Builds differing in %s should not be equal", tt.name)
+			}
+		})
+	}
+
+	var nilInfo *BuildInfo
+	if !nilInfo.ReproducibleEqual(nil) || nilInfo.ReproducibleEqual(base()) {
+		t.Error("nil handling is incorrect")
+	}
+}
@@ -0,0 +1,60 @@
+package release
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"strings"
+)
+
+// resolvConfPath is the resolver configuration file read on Linux
+var resolvConfPath = "/etc/resolv.conf"
+
+// ResolvConfValid reports whether /etc/resolv.conf exists and lists at
+// least one valid nameserver. It returns ErrNotSupported on non-Linux
+// platforms
+func ResolvConfValid() (bool, error) {
+	valid, _, err := resolvConf()
+	return valid, err
+}
+
+// ResolvConfCondition returns a condition that fails when
+// /etc/resolv.conf is missing or has no valid nameserver entries
+func ResolvConfCondition() Condition {
+	return newDetailedCondition("resolv-conf", "resolv.conf lists a nameserver", resolvConf)
+}
+
+// checkResolvConf validates resolv.conf contents, explaining the outcome
+func checkResolvConf(data string) (bool, string) {
+	var servers, invalid []string
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || fields[0] != "nameserver" {
+			continue
+		}
+		if len(fields) < 2 {
+			invalid = append(invalid, "(empty)")
+			continue
+		}
+		// IPv6 link-local servers may carry a zone, e.g. fe80::1%eth0
+		addr, _, _ := strings.Cut(fields[1], "%")
+		if net.ParseIP(addr) == nil {
+			invalid = append(invalid, fields[1])
+			continue
+		}
+		servers = append(servers, fields[1])
+	}
+
+	if len(servers) == 0 {
+		if len(invalid) > 0 {
+			return false, fmt.Sprintf("no valid nameserver entries, invalid: %s", strings.Join(invalid, ", "))
+		}
+		return false, "no nameserver entries"
+	}
+	detail := fmt.Sprintf("nameservers: %s", strings.Join(servers, ", "))
+	if len(invalid) > 0 {
+		detail += fmt.Sprintf("; ignored invalid: %s", strings.Join(invalid, ", "))
+	}
+	return true, detail
+}
@@ -0,0 +1,19 @@
+package release
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+)
+
+func resolvConf() (bool, string, error) {
+	data, err := os.ReadFile(resolvConfPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, resolvConfPath + " does not exist", nil
+	}
+	if err != nil {
+		return false, "", err
+	}
+	valid, detail := checkResolvConf(string(data))
+	return valid, detail, nil
+}
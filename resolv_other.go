@@ -0,0 +1,7 @@
+//go:build !linux
+
+package release
+
+func resolvConf() (bool, string, error) {
+	return false, "", ErrNotSupported
+}
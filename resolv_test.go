@@ -0,0 +1,58 @@
+package release
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestCheckResolvConf(t *testing.T) {
+	tests := []struct {
+		name       string
+		data       string
+		want       bool
+		wantDetail string
+	}{
+		{"single", "nameserver 10.0.0.2\n", true, "nameservers: 10.0.0.2"},
+		{"comments and options", "# generated\n; old\nsearch svc.cluster.local\nnameserver 10.96.0.10\noptions ndots:5\n", true, "10.96.0.10"},
+		{"ipv6 with zone", "nameserver fe80::1%eth0\nnameserver ::1\n", true, "fe80::1%eth0, ::1"},
+		{"some invalid", "nameserver bogus\nnameserver 1.1.1.1\n", true, "ignored invalid: bogus"},
+		{"empty", "", false, "no nameserver entries"},
+		{"search only", "search example.com\n", false, "no nameserver entries"},
+		{"all invalid", "nameserver\nnameserver dns.example.com\n", false, "(empty), dns.example.com"},
+	}
+	for _, tt := range tests {
+		got, detail := checkResolvConf(tt.data)
+		if got != tt.want || !strings.Contains(detail, tt.wantDetail) {
+			t.Errorf("%s: checkResolvConf() = %v, %q, want %v containing %q", tt.name, got, detail, tt.want, tt.wantDetail)
+		}
+	}
+}
+
+func TestResolvConfCondition(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		if _, err := ResolvConfValid(); !errors.Is(err, ErrNotSupported) {
+			t.Errorf("ResolvConfValid() error = %v, want ErrNotSupported", err)
+		}
+		return
+	}
+
+	dir := t.TempDir()
+	defer func(path string) { resolvConfPath = path }(resolvConfPath)
+
+	resolvConfPath = filepath.Join(dir, "missing")
+	if r := ResolvConfCondition().evaluate(); r.Passed || r.Error != nil || !strings.Contains(r.Detail, "does not exist") {
+		t.Errorf("Missing resolv.conf should fail without an error: %+v", r)
+	}
+
+	resolvConfPath = filepath.Join(dir, "resolv.conf")
+	if err := os.WriteFile(resolvConfPath, []byte("nameserver 8.8.8.8\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := ResolvConfValid(); !ok || err != nil {
+		t.Errorf("ResolvConfValid() = %v, %v, want true", ok, err)
+	}
+}
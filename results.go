@@ -0,0 +1,148 @@
+package release
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+)
+
+// MergeResults concatenates results from independently run condition
+// sets, preserving their order, so that a final verdict (AllPassed) can
+// be computed over the combined set
+func MergeResults(results ...TestResults) TestResults {
+	n := 0
+	for _, r := range results {
+		n += len(r)
+	}
+
+	merged := make(TestResults, 0, n)
+	for _, r := range results {
+		merged = append(merged, r...)
+	}
+	return merged
+}
+
+// Namespaced returns a copy of the results with every name prefixed by
+// prefix and a slash, e.g. "build/go-version", to disambiguate names
+// before merging results from different sets
+func (results TestResults) Namespaced(prefix string) TestResults {
+	namespaced := make(TestResults, len(results))
+	for i, r := range results {
+		r.Name = prefix + "/" + r.Name
+		namespaced[i] = r
+	}
+	return namespaced
+}
+
+// Fingerprint returns a hex SHA-256 over the (name, outcome) pairs of the
+// results in order, where the outcome is TestResult.OK. Two runs have the
+// same fingerprint when the same conditions passed and failed, so it can
+// be compared across runs to detect state transitions cheaply. Details,
+// errors and timings do not affect it
+func (results TestResults) Fingerprint() string {
+	h := sha256.New()
+	for _, r := range results {
+		// Length-prefix names so that distinct lists cannot collide
+		h.Write([]byte(strconv.Itoa(len(r.Name))))
+		h.Write([]byte{':'})
+		h.Write([]byte(r.Name))
+		if r.OK() {
+			h.Write([]byte{1})
+		} else {
+			h.Write([]byte{0})
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Regressions returns the names of the conditions that passed in
+// baseline but fail now, in the order of results. Conditions missing
+// from either run or skipped in either run are ignored, so long-standing
+// failures are not re-reported
+func (results TestResults) Regressions(baseline TestResults) []string {
+	return results.transitions(baseline, true)
+}
+
+// Improvements returns the names of the conditions that failed in
+// baseline but pass now, the reverse of Regressions
+func (results TestResults) Improvements(baseline TestResults) []string {
+	return results.transitions(baseline, false)
+}
+
+// transitions returns the names whose outcome changed from wasOK in
+// baseline to !wasOK now. Each name is reported once
+func (results TestResults) transitions(baseline TestResults, wasOK bool) []string {
+	before := make(map[string]bool, len(baseline))
+	for _, r := range baseline {
+		if !r.Skipped {
+			before[r.Name] = r.OK()
+		}
+	}
+
+	var names []string
+	seen := make(map[string]bool)
+	for _, r := range results {
+		ok, ran := before[r.Name]
+		if !ran || r.Skipped || seen[r.Name] || ok != wasOK || r.OK() == wasOK {
+			continue
+		}
+		seen[r.Name] = true
+		names = append(names, r.Name)
+	}
+	return names
+}
+
+// FailedResults returns the results that are not OK (see TestResult.OK),
+// in order. With Go 1.23 and later, Failures iterates over the same
+// results without allocating
+func (results TestResults) FailedResults() TestResults {
+	var failed TestResults
+	for _, r := range results {
+		if !r.OK() {
+			failed = append(failed, r)
+		}
+	}
+	return failed
+}
+
+// Warnings returns the results that pass only because their condition is
+// advisory: the check errored without panicking but ErrorIsPass is set.
+// Warnings never block, unlike the failures returned by FailedResults, so
+// reports list them separately to allow alerting on them at a lower
+// severity
+func (results TestResults) Warnings() TestResults {
+	var warnings TestResults
+	for _, r := range results {
+		if !r.Skipped && r.Error != nil && r.OK() {
+			warnings = append(warnings, r)
+		}
+	}
+	return warnings
+}
+
+// names returns the names of the results, never nil
+func (results TestResults) names() []string {
+	names := make([]string, len(results))
+	for i, r := range results {
+		names[i] = r.Name
+	}
+	return names
+}
+
+// ExitCode returns the process exit code for the results: 0 when all are
+// OK, otherwise the ExitCode of the first failed result that carries one,
+// so conditions added earlier take priority. Failures without an exit
+// code yield 1
+func (results TestResults) ExitCode() int {
+	code := 0
+	for _, r := range results {
+		if r.OK() {
+			continue
+		}
+		if r.ExitCode != 0 {
+			return r.ExitCode
+		}
+		code = 1
+	}
+	return code
+}
@@ -0,0 +1,156 @@
+package release
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestMergeResults(t *testing.T) {
+	build := TestResults{{Name: "go-version", Passed: true}, {Name: "trimpath", Passed: true}}
+	deploy := TestResults{{Name: "go-version", Passed: false}}
+
+	merged := MergeResults(build.Namespaced("build"), deploy.Namespaced("deploy"))
+	if len(merged) != 3 {
+		t.Fatalf("Expected 3 results, got %d", len(merged))
+	}
+
+	want := []string{"build/go-version", "build/trimpath", "deploy/go-version"}
+	for i, name := range want {
+		if merged[i].Name != name {
+			t.Errorf("merged[%d].Name = %s, want %s", i, merged[i].Name, name)
+		}
+	}
+	if merged.AllPassed() {
+		t.Error("Merged results should fail when any stage failed")
+	}
+	if build[0].Name != "go-version" {
+		t.Error("Namespaced should not modify the original results")
+	}
+
+	if len(MergeResults()) != 0 {
+		t.Error("Merging nothing should produce no results")
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	base := TestResults{{Name: "a", Passed: true}, {Name: "b", Passed: false, Detail: "x"}}
+
+	same := TestResults{{Name: "a", Passed: true}, {Name: "b", Passed: false, Detail: "y", Error: nil}}
+	if base.Fingerprint() != same.Fingerprint() {
+		t.Error("Details should not affect the fingerprint")
+	}
+
+	changed := TestResults{{Name: "a", Passed: true}, {Name: "b", Passed: true}}
+	if base.Fingerprint() == changed.Fingerprint() {
+		t.Error("A changed outcome should change the fingerprint")
+	}
+
+	reordered := TestResults{base[1], base[0]}
+	if base.Fingerprint() == reordered.Fingerprint() {
+		t.Error("Order should affect the fingerprint")
+	}
+
+	errored := TestResults{{Name: "a", Passed: true}, {Name: "b", Passed: false, Error: errors.New("boom")}}
+	if base.Fingerprint() != errored.Fingerprint() {
+		t.Error("Failed and errored outcomes should fingerprint the same")
+	}
+
+	ambiguous1 := TestResults{{Name: "ab", Passed: true}}
+	ambiguous2 := TestResults{{Name: "a", Passed: true}, {Name: "b", Passed: true}}
+	if ambiguous1.Fingerprint() == ambiguous2.Fingerprint() {
+		t.Error("Different name lists should not collide")
+	}
+
+	if len(base.Fingerprint()) != 64 {
+		t.Errorf("Fingerprint should be a hex SHA-256, got %q", base.Fingerprint())
+	}
+}
+
+func TestRegressionsAndImprovements(t *testing.T) {
+	baseline := TestResults{
+		{Name: "go-version", Passed: true},
+		{Name: "db", Passed: true},
+		{Name: "dns", Passed: false},
+		{Name: "known-issue", Passed: false},
+		{Name: "removed", Passed: true},
+		{Name: "skipped-now", Passed: true},
+	}
+	current := TestResults{
+		{Name: "go-version", Passed: true},
+		{Name: "db", Error: errors.New("connection refused")},
+		{Name: "dns", Passed: true},
+		{Name: "known-issue", Passed: false},
+		{Name: "added", Passed: false},
+		{Name: "skipped-now", Skipped: true},
+	}
+
+	if got := current.Regressions(baseline); !reflect.DeepEqual(got, []string{"db"}) {
+		t.Errorf("Regressions() = %v, want [db]", got)
+	}
+	if got := current.Improvements(baseline); !reflect.DeepEqual(got, []string{"dns"}) {
+		t.Errorf("Improvements() = %v, want [dns]", got)
+	}
+	if got := current.Regressions(nil); got != nil {
+		t.Errorf("Regressions(nil) = %v, want nil", got)
+	}
+}
+
+func TestFailedResults(t *testing.T) {
+	results := TestResults{
+		{Name: "a", Passed: true},
+		{Name: "b", Passed: false},
+		{Name: "c", Skipped: true},
+		{Name: "d", Error: errors.New("boom")},
+	}
+	failed := results.FailedResults()
+	if len(failed) != 2 || failed[0].Name != "b" || failed[1].Name != "d" {
+		t.Errorf("FailedResults() = %+v, want b and d", failed)
+	}
+	if got := (TestResults{{Name: "a", Passed: true}}).FailedResults(); got != nil {
+		t.Errorf("FailedResults() with no failures = %+v, want nil", got)
+	}
+}
+
+func TestWarnings(t *testing.T) {
+	results := TestResults{
+		{Name: "required", Error: errors.New("boom")},
+		{Name: "advisory", ErrorIsPass: true, Error: errors.New("timeout")},
+		{Name: "advisory-false", ErrorIsPass: true, Passed: false},
+		{Name: "advisory-panic", ErrorIsPass: true, Error: &PanicError{Value: "boom"}},
+		{Name: "skipped", ErrorIsPass: true, Skipped: true},
+		{Name: "ok", Passed: true},
+	}
+	if got := results.Warnings().names(); !reflect.DeepEqual(got, []string{"advisory"}) {
+		t.Errorf("Warnings() = %v, want [advisory]", got)
+	}
+	if got := results.FailedResults().names(); !reflect.DeepEqual(got, []string{"required", "advisory-false", "advisory-panic"}) {
+		t.Errorf("FailedResults() = %v, want [required advisory-false advisory-panic]", got)
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	tooOld := TestResult{Name: "go-version", Passed: false, ExitCode: 10}
+	platform := TestResult{Name: "platform", Passed: false, ExitCode: 11}
+	plain := TestResult{Name: "locale", Passed: false}
+	passed := TestResult{Name: "ok", Passed: true, ExitCode: 12}
+	advisory := TestResult{Name: "db", Error: errors.New("timeout"), ErrorIsPass: true, ExitCode: 13}
+
+	tests := []struct {
+		name    string
+		results TestResults
+		want    int
+	}{
+		{"all passed", TestResults{passed, advisory}, 0},
+		{"empty", nil, 0},
+		{"no exit code", TestResults{passed, plain}, 1},
+		{"first coded failure wins", TestResults{plain, platform, tooOld}, 11},
+		{"passing codes ignored", TestResults{passed, advisory, tooOld}, 10},
+		{"errored", TestResults{{Name: "x", Error: errors.New("boom"), ExitCode: 14}}, 14},
+	}
+	for _, tt := range tests {
+		if got := tt.results.ExitCode(); got != tt.want {
+			t.Errorf("%s: ExitCode() = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
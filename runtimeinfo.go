@@ -0,0 +1,21 @@
+package release
+
+// RuntimeInfo contains information about the environment the process is
+// running in, as opposed to how the binary was built (see BuildInfo)
+type RuntimeInfo struct {
+	Kubernetes          bool
+	KubernetesNamespace string
+	Systemd             bool
+	SystemdUnit         string
+}
+
+// GetRuntimeInfo returns information about the current runtime environment
+func GetRuntimeInfo() *RuntimeInfo {
+	info := &RuntimeInfo{
+		Kubernetes: IsKubernetes(),
+		Systemd:    IsSystemdManaged(),
+	}
+	info.KubernetesNamespace, _ = KubernetesNamespace()
+	info.SystemdUnit, _ = SystemdUnit()
+	return info
+}
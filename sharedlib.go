@@ -0,0 +1,37 @@
+package release
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SharedLibraryAvailable reports whether the dynamic loader can load the
+// named shared library, e.g. "libssl.so.3", by attempting a dlopen and
+// closing the handle again. A library that cannot be loaded reports false
+// without an error. It is only available in cgo builds on Linux and
+// returns ErrNotSupported elsewhere
+func SharedLibraryAvailable(name string) (bool, error) {
+	ok, _, err := dlopenCheck(name)
+	return ok, err
+}
+
+// RequiredSharedLibsCondition returns a condition that fails when any of
+// the named shared libraries cannot be loaded, listing the missing ones
+func RequiredSharedLibsCondition(names ...string) Condition {
+	return newDetailedCondition("required-shared-libs", fmt.Sprintf("Shared libraries loadable: %s", strings.Join(names, ", ")), func() (bool, string, error) {
+		var missing []string
+		for _, name := range names {
+			ok, reason, err := dlopenCheck(name)
+			if err != nil {
+				return false, "", err
+			}
+			if !ok {
+				missing = append(missing, reason)
+			}
+		}
+		if len(missing) > 0 {
+			return false, "cannot load: " + strings.Join(missing, "; "), nil
+		}
+		return true, fmt.Sprintf("loaded %d shared libraries", len(names)), nil
+	})
+}
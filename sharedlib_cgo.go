@@ -0,0 +1,39 @@
+//go:build cgo && linux
+
+package release
+
+/*
+#cgo LDFLAGS: -ldl
+#include <dlfcn.h>
+#include <stdio.h>
+#include <stdlib.h>
+
+// try_dlopen loads and unloads name, copying the loader's error into buf
+// on failure. dlerror is per-thread, so it must be read in the same call
+static int try_dlopen(const char *name, char *buf, size_t len) {
+	void *handle = dlopen(name, RTLD_LAZY | RTLD_LOCAL);
+	if (handle == NULL) {
+		const char *err = dlerror();
+		snprintf(buf, len, "%s", err != NULL ? err : name);
+		return 0;
+	}
+	dlclose(handle);
+	return 1;
+}
+*/
+import "C"
+
+import "unsafe"
+
+// dlopenCheck tries to load name, returning the loader's error message
+// when it cannot
+func dlopenCheck(name string) (bool, string, error) {
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+
+	var buf [512]C.char
+	if C.try_dlopen(cname, &buf[0], C.size_t(len(buf))) == 0 {
+		return false, C.GoString(&buf[0]), nil
+	}
+	return true, "", nil
+}
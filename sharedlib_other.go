@@ -0,0 +1,7 @@
+//go:build !cgo || !linux
+
+package release
+
+func dlopenCheck(name string) (bool, string, error) {
+	return false, "", ErrNotSupported
+}
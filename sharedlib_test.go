@@ -0,0 +1,36 @@
+package release
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSharedLibraryAvailable(t *testing.T) {
+	// libc is loaded into every cgo process on Linux
+	ok, err := SharedLibraryAvailable("libc.so.6")
+	if errors.Is(err, ErrNotSupported) {
+		if r := RequiredSharedLibsCondition("libc.so.6").evaluate(); !errors.Is(r.Error, ErrNotSupported) {
+			t.Errorf("Expected ErrNotSupported, got %+v", r)
+		}
+		t.Skip("shared library checks need cgo on Linux")
+	}
+	if err != nil {
+		t.Fatalf("SharedLibraryAvailable() error = %v", err)
+	}
+	if !ok {
+		t.Skip("libc.so.6 not available (non-glibc system?)")
+	}
+
+	if ok, err := SharedLibraryAvailable("libdoesnotexist.so.42"); ok || err != nil {
+		t.Errorf("SharedLibraryAvailable(missing) = %v, %v, want false", ok, err)
+	}
+
+	r := RequiredSharedLibsCondition("libc.so.6", "libdoesnotexist.so.42").evaluate()
+	if r.Passed || !strings.Contains(r.Detail, "libdoesnotexist.so.42") {
+		t.Errorf("RequiredSharedLibsCondition() = %+v", r)
+	}
+	if r := RequiredSharedLibsCondition("libc.so.6").evaluate(); !r.Passed {
+		t.Errorf("RequiredSharedLibsCondition(libc) = %+v", r)
+	}
+}
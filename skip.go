@@ -0,0 +1,58 @@
+package release
+
+import (
+	"context"
+	"os"
+	"strings"
+)
+
+// SkipEnvVar is the env var holding a comma-separated list of condition
+// names to skip, e.g. RELEASE_SKIP_CHECKS=db-reachable,dns
+//
+// This is an operational safety valve for false-positive gates during
+// incidents. Anyone able to set the variable can waive any condition, so
+// skipped conditions always appear in results (Skipped, SkipReason) and
+// should be reviewed and removed once the incident is over
+const SkipEnvVar = "RELEASE_SKIP_CHECKS"
+
+// SkipChecks marks the named conditions as skipped in all subsequent
+// runs, in addition to those listed in SkipEnvVar. It is the programmatic
+// equivalent of the env var, e.g. for tests
+func (cs *ConditionSet) SkipChecks(names ...string) {
+	if cs.skipped == nil {
+		cs.skipped = make(map[string]bool, len(names))
+	}
+	for _, name := range names {
+		cs.skipped[name] = true
+	}
+}
+
+// skipReasons returns the names of the conditions to skip in a run, with
+// the reason recorded for each. The env var is read on every run
+func (cs *ConditionSet) skipReasons() map[string]string {
+	reasons := make(map[string]string)
+	for name := range cs.skipped {
+		reasons[name] = "skipped via SkipChecks"
+	}
+	for _, name := range strings.Split(os.Getenv(SkipEnvVar), ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			reasons[name] = "skipped via " + SkipEnvVar
+		}
+	}
+	return reasons
+}
+
+// evaluateUnlessSkipped evaluates cond within the run of ctx, or records it
+// as skipped without running its check
+func evaluateUnlessSkipped(ctx context.Context, cond Condition, skips map[string]string) TestResult {
+	if reason, ok := skips[cond.Name]; ok {
+		return TestResult{
+			Name:        cond.Name,
+			Description: cond.Description,
+			Skipped:     true,
+			SkipReason:  reason,
+			ErrorIsPass: cond.ErrorIsPass,
+		}
+	}
+	return cond.evaluateIn(ctx)
+}
@@ -0,0 +1,53 @@
+package release
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestSkipChecks(t *testing.T) {
+	ran := map[string]bool{}
+	cs := NewConditionSet()
+	for _, name := range []string{"db", "dns", "cache"} {
+		name := name
+		cs.Add(name, "Check "+name, func() (bool, error) {
+			ran[name] = true
+			return false, nil
+		})
+	}
+
+	t.Setenv(SkipEnvVar, " dns , ")
+	cs.SkipChecks("db")
+
+	results := cs.TestAll()
+	if ran["db"] || ran["dns"] || !ran["cache"] {
+		t.Errorf("Unexpected runs: %v", ran)
+	}
+
+	if !results[0].Skipped || results[0].SkipReason != "skipped via SkipChecks" {
+		t.Errorf("db should be skipped via SkipChecks: %+v", results[0])
+	}
+	if !results[1].Skipped || !strings.Contains(results[1].SkipReason, SkipEnvVar) {
+		t.Errorf("dns should be skipped via the env var: %+v", results[1])
+	}
+	if results[2].Skipped || results[2].OK() {
+		t.Errorf("cache should run and fail: %+v", results[2])
+	}
+
+	cs.SkipChecks("cache")
+	if !cs.TestAll().AllPassed() {
+		t.Error("Skipped conditions should not fail the set")
+	}
+	if r := cs.TestOnly("cache"); !r[0].Skipped {
+		t.Error("TestOnly should honor skips")
+	}
+
+	var buf bytes.Buffer
+	if err := cs.TestAll().WriteTable(&buf, ReportOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "[SKIP]") {
+		t.Errorf("Table should mark skipped conditions:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,67 @@
+package release
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// StandardStreamsValid reports whether stdout and stderr are open,
+// writable and not redirected to the null device, so the process's
+// output and logs are not silently dropped
+func StandardStreamsValid() (bool, error) {
+	valid, _, err := standardStreams()
+	return valid, err
+}
+
+// StdStreamsCondition returns a condition that fails when stdout or
+// stderr is closed, not writable or redirected to the null device,
+// e.g. when a supervisor starts the process with closed descriptors.
+// The detail names the bad streams
+func StdStreamsCondition() Condition {
+	return newDetailedCondition("std-streams", "stdout and stderr are connected", standardStreams)
+}
+
+// namedStream is an output stream and the name used to report it
+type namedStream struct {
+	name string
+	file *os.File
+}
+
+func standardStreams() (bool, string, error) {
+	ok, detail := checkStreams([]namedStream{{"stdout", os.Stdout}, {"stderr", os.Stderr}})
+	return ok, detail, nil
+}
+
+// checkStreams probes each stream with a stat and a zero-byte write,
+// explaining which ones are unusable
+func checkStreams(streams []namedStream) (bool, string) {
+	devNull, _ := os.Stat(os.DevNull)
+
+	var problems, names []string
+	for _, s := range streams {
+		names = append(names, s.name)
+		if problem := checkStream(s.file, devNull); problem != "" {
+			problems = append(problems, fmt.Sprintf("%s %s", s.name, problem))
+		}
+	}
+	if len(problems) > 0 {
+		return false, strings.Join(problems, "; ")
+	}
+	return true, fmt.Sprintf("%s writable", strings.Join(names, " and "))
+}
+
+// checkStream returns why f is unusable for output, or "" if it is fine
+func checkStream(f *os.File, devNull os.FileInfo) string {
+	fi, err := f.Stat()
+	if err != nil {
+		return fmt.Sprintf("is closed or invalid: %v", err)
+	}
+	if devNull != nil && os.SameFile(fi, devNull) {
+		return "is redirected to " + os.DevNull
+	}
+	if _, err := f.Write(nil); err != nil {
+		return fmt.Sprintf("is not writable: %v", err)
+	}
+	return ""
+}
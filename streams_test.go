@@ -0,0 +1,67 @@
+package release
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCheckStreams(t *testing.T) {
+	dir := t.TempDir()
+
+	writable, err := os.Create(filepath.Join(dir, "log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer writable.Close()
+
+	readOnly, err := os.Open(writable.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer readOnly.Close()
+
+	closed, err := os.Create(filepath.Join(dir, "closed"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	closed.Close()
+
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer devNull.Close()
+
+	tests := []struct {
+		name    string
+		streams []namedStream
+		want    bool
+		detail  string
+	}{
+		{"writable", []namedStream{{"stdout", writable}, {"stderr", writable}}, true, "stdout and stderr writable"},
+		{"closed", []namedStream{{"stdout", writable}, {"stderr", closed}}, false, "stderr is closed"},
+		{"read-only", []namedStream{{"stdout", readOnly}, {"stderr", writable}}, false, "stdout is not writable"},
+		{"null device", []namedStream{{"stdout", devNull}, {"stderr", writable}}, false, "stdout is redirected to " + os.DevNull},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, detail := checkStreams(tt.streams)
+			if ok != tt.want || !strings.Contains(detail, tt.detail) {
+				t.Errorf("checkStreams() = %v, %q, want %v containing %q", ok, detail, tt.want, tt.detail)
+			}
+		})
+	}
+}
+
+func TestStdStreamsCondition(t *testing.T) {
+	r := StdStreamsCondition().evaluate()
+	if r.Error != nil || r.Detail == "" {
+		t.Errorf("StdStreamsCondition() = %+v", r)
+	}
+	if ok, _ := StandardStreamsValid(); ok != r.Passed {
+		t.Errorf("StandardStreamsValid() = %v, condition passed = %v", ok, r.Passed)
+	}
+}
@@ -0,0 +1,54 @@
+package release
+
+import (
+	"bufio"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// SwapTotal returns the total swap space in bytes, from /proc/meminfo. It
+// returns ErrNotSupported on non-Linux platforms
+func SwapTotal() (uint64, error) {
+	return swapTotal()
+}
+
+// MinSwapCondition returns a condition that fails when less than the
+// given number of bytes of swap is configured
+func MinSwapCondition(bytes uint64) Condition {
+	return newDetailedCondition("min-swap", fmt.Sprintf("At least %d bytes of swap configured", bytes), func() (bool, string, error) {
+		total, err := SwapTotal()
+		if err != nil {
+			return false, "", err
+		}
+		return total >= bytes, fmt.Sprintf("swap total is %d bytes, need %d", total, bytes), nil
+	})
+}
+
+// meminfoValue returns a field of /proc/meminfo contents in bytes, e.g.
+// "SwapTotal:  2097148 kB"
+func meminfoValue(data, field string) (uint64, error) {
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	for scanner.Scan() {
+		name, rest, ok := strings.Cut(scanner.Text(), ":")
+		if !ok || name != field {
+			continue
+		}
+		fields := strings.Fields(rest)
+		if len(fields) == 0 {
+			return 0, fmt.Errorf("meminfo: empty %s", field)
+		}
+		v, err := strconv.ParseUint(fields[0], 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("meminfo: invalid %s: %w", field, err)
+		}
+		if len(fields) > 1 && fields[1] == "kB" {
+			v *= 1024
+		}
+		return v, nil
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, err
+	}
+	return 0, fmt.Errorf("meminfo: %s not found", field)
+}
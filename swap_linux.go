@@ -0,0 +1,14 @@
+package release
+
+import "os"
+
+// meminfoPath is the procfs file holding memory statistics
+var meminfoPath = "/proc/meminfo"
+
+func swapTotal() (uint64, error) {
+	data, err := os.ReadFile(meminfoPath)
+	if err != nil {
+		return 0, err
+	}
+	return meminfoValue(string(data), "SwapTotal")
+}
@@ -0,0 +1,7 @@
+//go:build !linux
+
+package release
+
+func swapTotal() (uint64, error) {
+	return 0, ErrNotSupported
+}
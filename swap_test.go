@@ -0,0 +1,54 @@
+package release
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestMeminfoValue(t *testing.T) {
+	const meminfo = "MemTotal:       16318480 kB\nSwapCached:            0 kB\nSwapTotal:       2097148 kB\nHugePages_Total:       0\n"
+	tests := []struct {
+		field   string
+		want    uint64
+		wantErr bool
+	}{
+		{"SwapTotal", 2097148 * 1024, false},
+		{"MemTotal", 16318480 * 1024, false},
+		{"HugePages_Total", 0, false},
+		{"Swap", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := meminfoValue(meminfo, tt.field)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("meminfoValue(%q) error = %v, wantErr %v", tt.field, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("meminfoValue(%q) = %d, want %d", tt.field, got, tt.want)
+		}
+	}
+	if _, err := meminfoValue("SwapTotal: lots kB\n", "SwapTotal"); err == nil {
+		t.Error("meminfoValue() should reject a non-numeric value")
+	}
+}
+
+func TestMinSwapCondition(t *testing.T) {
+	total, err := SwapTotal()
+	if errors.Is(err, ErrNotSupported) {
+		if r := MinSwapCondition(1).evaluate(); !errors.Is(r.Error, ErrNotSupported) {
+			t.Errorf("Expected ErrNotSupported, got %+v", r)
+		}
+		return
+	}
+	if err != nil {
+		t.Skipf("cannot read meminfo: %v", err)
+	}
+
+	if r := MinSwapCondition(total).evaluate(); !r.Passed {
+		t.Errorf("MinSwapCondition(%d) should pass: %+v", total, r)
+	}
+	if r := MinSwapCondition(total + 1).evaluate(); r.Passed || !strings.Contains(r.Detail, "swap total is") {
+		t.Errorf("MinSwapCondition(%d) should fail with the detected total: %+v", total+1, r)
+	}
+}
@@ -0,0 +1,65 @@
+package release
+
+import (
+	"runtime/debug"
+	"strings"
+)
+
+// HasDebugSymbols reports whether the binary appears to carry debug
+// symbols, judging from the -ldflags build setting: it is considered
+// stripped only when built with both -s (no symbol table) and -w (no
+// DWARF). This is a heuristic. known is false when no -ldflags setting
+// was recorded, e.g. when built without -ldflags or without build info
+func HasDebugSymbols() (present, known bool) {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return false, false
+	}
+	return debugSymbolsFromSettings(info.Settings)
+}
+
+// StrippedBinaryCondition returns a condition that fails unless the
+// binary was built with -ldflags "-s -w". Binaries without a recorded
+// -ldflags setting fail too, since they were most likely not stripped
+func StrippedBinaryCondition() Condition {
+	return newDetailedCondition("stripped-binary", `Built with -ldflags "-s -w"`, func() (bool, string, error) {
+		present, known := HasDebugSymbols()
+		switch {
+		case !known:
+			return false, "no -ldflags build setting recorded, assuming debug symbols are present", nil
+		case present:
+			return false, "-ldflags lacks -s and/or -w, debug symbols are present", nil
+		}
+		return true, "built with -s -w", nil
+	})
+}
+
+// debugSymbolsFromSettings applies the HasDebugSymbols heuristic to
+// build settings
+func debugSymbolsFromSettings(settings []debug.BuildSetting) (present, known bool) {
+	for _, setting := range settings {
+		if setting.Key == "-ldflags" {
+			stripped := ldflagSet(setting.Value, "s") && ldflagSet(setting.Value, "w")
+			return !stripped, true
+		}
+	}
+	return false, false
+}
+
+// ldflagSet reports whether a boolean linker flag is enabled in ldflags,
+// accepting -name, --name and -name=true. The last occurrence wins
+func ldflagSet(ldflags, name string) bool {
+	set := false
+	for _, field := range strings.Fields(ldflags) {
+		field = strings.Trim(field, `"'`)
+		if !strings.HasPrefix(field, "-") {
+			continue
+		}
+		flag, value, hasValue := strings.Cut(strings.TrimLeft(field, "-"), "=")
+		if flag != name {
+			continue
+		}
+		set = !hasValue || value == "true" || value == "1"
+	}
+	return set
+}
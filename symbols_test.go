@@ -0,0 +1,46 @@
+package release
+
+import (
+	"runtime/debug"
+	"testing"
+)
+
+func TestDebugSymbolsFromSettings(t *testing.T) {
+	tests := []struct {
+		ldflags     string
+		recorded    bool
+		wantPresent bool
+		wantKnown   bool
+	}{
+		{"-s -w", true, false, true},
+		{"-s -w -X main.version=1.2.3", true, false, true},
+		{`"-s" "-w"`, true, false, true},
+		{"--s=true -w=1", true, false, true},
+		{"-s", true, true, true},
+		{"-w", true, true, true},
+		{"-s -w -s=false", true, true, true},
+		{"-X main.sha=-s", true, true, true},
+		{"", false, false, false},
+	}
+	for _, tt := range tests {
+		settings := []debug.BuildSetting{{Key: "GOOS", Value: "linux"}}
+		if tt.recorded {
+			settings = append(settings, debug.BuildSetting{Key: "-ldflags", Value: tt.ldflags})
+		}
+		present, known := debugSymbolsFromSettings(settings)
+		if present != tt.wantPresent || known != tt.wantKnown {
+			t.Errorf("debugSymbolsFromSettings(-ldflags=%q) = %v, %v, want %v, %v", tt.ldflags, present, known, tt.wantPresent, tt.wantKnown)
+		}
+	}
+}
+
+func TestStrippedBinaryCondition(t *testing.T) {
+	present, known := HasDebugSymbols()
+	r := StrippedBinaryCondition().evaluate()
+	if r.Error != nil {
+		t.Fatalf("StrippedBinaryCondition() error = %v", r.Error)
+	}
+	if r.Passed != (known && !present) {
+		t.Errorf("StrippedBinaryCondition() = %+v with present=%v known=%v", r, present, known)
+	}
+}
@@ -0,0 +1,46 @@
+package release
+
+import (
+	"os"
+	"strings"
+)
+
+// IsSystemdManaged reports whether the process was started by systemd,
+// based on the INVOCATION_ID and JOURNAL_STREAM env vars systemd sets for
+// the processes of a unit
+func IsSystemdManaged() bool {
+	return os.Getenv("INVOCATION_ID") != "" || os.Getenv("JOURNAL_STREAM") != ""
+}
+
+// SystemdUnit returns the name of the systemd service the process runs
+// in, e.g. "api.service", from its cgroup path. It returns false when the
+// process is not managed by systemd or the unit cannot be determined, e.g.
+// on non-Linux platforms
+func SystemdUnit() (string, bool) {
+	if !IsSystemdManaged() {
+		return "", false
+	}
+	data, err := os.ReadFile(procSelfCgroupPath)
+	if err != nil {
+		return "", false
+	}
+	return systemdUnitFromCgroup(string(data))
+}
+
+// systemdUnitFromCgroup returns the innermost service in the cgroup paths
+// of /proc/self/cgroup contents, e.g. "0::/system.slice/api.service"
+func systemdUnitFromCgroup(cgroups string) (string, bool) {
+	for _, line := range strings.Split(cgroups, "\n") {
+		parts := strings.SplitN(line, ":", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		segments := strings.Split(parts[2], "/")
+		for i := len(segments) - 1; i >= 0; i-- {
+			if strings.HasSuffix(segments[i], ".service") {
+				return segments[i], true
+			}
+		}
+	}
+	return "", false
+}
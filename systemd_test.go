@@ -0,0 +1,72 @@
+package release
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestIsSystemdManaged(t *testing.T) {
+	tests := []struct {
+		invocationID  string
+		journalStream string
+		want          bool
+	}{
+		{"", "", false},
+		{"8a1c4e2f9b7d", "", true},
+		{"", "8:12345", true},
+	}
+	for _, tt := range tests {
+		t.Setenv("INVOCATION_ID", tt.invocationID)
+		t.Setenv("JOURNAL_STREAM", tt.journalStream)
+		if got := IsSystemdManaged(); got != tt.want {
+			t.Errorf("IsSystemdManaged() with INVOCATION_ID=%q JOURNAL_STREAM=%q = %v, want %v", tt.invocationID, tt.journalStream, got, tt.want)
+		}
+	}
+}
+
+func TestSystemdUnitFromCgroup(t *testing.T) {
+	tests := []struct {
+		cgroups string
+		want    string
+		wantOK  bool
+	}{
+		{"0::/system.slice/api.service\n", "api.service", true},
+		{"0::/user.slice/user-1000.slice/user@1000.service/app.slice/worker.service\n", "worker.service", true},
+		{"12:pids:/system.slice/api.service\n1:name=systemd:/system.slice/api.service\n0::/\n", "api.service", true},
+		{"0::/docker/abc123\n", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := systemdUnitFromCgroup(tt.cgroups)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("systemdUnitFromCgroup(%q) = %q, %v; want %q, %v", tt.cgroups, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestSystemdUnit(t *testing.T) {
+	orig := procSelfCgroupPath
+	defer func() { procSelfCgroupPath = orig }()
+	procSelfCgroupPath = filepath.Join(t.TempDir(), "cgroup")
+	if err := os.WriteFile(procSelfCgroupPath, []byte("0::/system.slice/api.service\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("INVOCATION_ID", "")
+	t.Setenv("JOURNAL_STREAM", "")
+	if _, ok := SystemdUnit(); ok {
+		t.Error("SystemdUnit should return false outside systemd")
+	}
+
+	t.Setenv("INVOCATION_ID", "8a1c4e2f9b7d")
+	unit, ok := SystemdUnit()
+	if !ok || unit != "api.service" {
+		t.Errorf("SystemdUnit() = %q, %v; want \"api.service\", true", unit, ok)
+	}
+
+	info := GetRuntimeInfo()
+	if !info.Systemd || info.SystemdUnit != "api.service" {
+		t.Errorf("Unexpected RuntimeInfo: %+v", info)
+	}
+}
@@ -0,0 +1,83 @@
+package release
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// gitOutput runs git with the given arguments and returns its trimmed
+// stdout, replaceable in tests
+var gitOutput = func(args ...string) (string, error) {
+	var stderr bytes.Buffer
+	cmd := exec.Command("git", args...)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("git %s: %w: %s", strings.Join(args, " "), err, msg)
+		}
+		return "", fmt.Errorf("git %s: %w", strings.Join(args, " "), err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// NearestTag returns the nearest tag reachable from HEAD of the git
+// repository in the working directory, using `git describe --tags`
+// This requires git to be installed and is meant as a fallback when the
+// build has no embedded vcs.tag
+func NearestTag() (string, error) {
+	return gitOutput("describe", "--tags", "--abbrev=0")
+}
+
+// TaggedBuildCondition returns a condition that passes only if the build
+// corresponds exactly to a tag: either the embedded vcs.tag setting, or,
+// when that is absent, a tag pointing at the embedded vcs.revision as
+// reported by git (which must then be installed)
+func TaggedBuildCondition() Condition {
+	return newDetailedCondition("tagged-build", "Build corresponds to a VCS tag", func() (bool, string, error) {
+		return checkTaggedBuild(GetBuildInfo())
+	})
+}
+
+// checkTaggedBuild evaluates whether info corresponds to a tag
+func checkTaggedBuild(info *BuildInfo) (bool, string, error) {
+	if info.VCSTag != "" {
+		return true, fmt.Sprintf("built from tag %s", info.VCSTag), nil
+	}
+	if info.VCSRevision == "" {
+		return false, "build has no VCS information", nil
+	}
+
+	tag, err := gitOutput("describe", "--tags", "--exact-match", info.VCSRevision)
+	if err != nil {
+		if isUntaggedError(err) {
+			return false, fmt.Sprintf("revision %s is not tagged", info.VCSRevision), nil
+		}
+		return false, "", err
+	}
+	return true, fmt.Sprintf("revision %s is tagged %s", info.VCSRevision, tag), nil
+}
+
+// untaggedMessages are the stderr messages of git describe --exact-match
+// for a revision without a tag. Other failures, e.g. an unknown revision
+// or a directory that is not a repository, exit with the same status
+var untaggedMessages = []string{"no tag exactly matches", "no names found"}
+
+// isUntaggedError reports whether err, returned by gitOutput, means that
+// the revision has no tag
+func isUntaggedError(err error) bool {
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) {
+		return false
+	}
+	msg := strings.ToLower(err.Error())
+	for _, untagged := range untaggedMessages {
+		if strings.Contains(msg, untagged) {
+			return true
+		}
+	}
+	return false
+}
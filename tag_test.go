@@ -0,0 +1,67 @@
+package release
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime/debug"
+	"strings"
+	"testing"
+)
+
+func TestVCSTagSetting(t *testing.T) {
+	info := &BuildInfo{}
+	info.applySettings([]debug.BuildSetting{{Key: "vcs.tag", Value: "v1.2.3"}})
+	if info.VCSTag != "v1.2.3" {
+		t.Errorf("VCSTag = %q, want v1.2.3", info.VCSTag)
+	}
+}
+
+func TestCheckTaggedBuild(t *testing.T) {
+	orig := gitOutput
+	defer func() { gitOutput = orig }()
+
+	if ok, _, err := checkTaggedBuild(&BuildInfo{VCSTag: "v1.0.0"}); !ok || err != nil {
+		t.Errorf("Embedded tag: got %v, %v; want true, nil", ok, err)
+	}
+
+	if ok, detail, _ := checkTaggedBuild(&BuildInfo{}); ok || detail == "" {
+		t.Errorf("No VCS info: got %v, %q; want false with a detail", ok, detail)
+	}
+
+	gitOutput = func(args ...string) (string, error) {
+		return "v2.0.0", nil
+	}
+	if ok, _, err := checkTaggedBuild(&BuildInfo{VCSRevision: "abc"}); !ok || err != nil {
+		t.Errorf("Tagged revision: got %v, %v; want true, nil", ok, err)
+	}
+
+	for _, msg := range []string{"fatal: no tag exactly matches 'abc'", "fatal: No names found, cannot describe anything."} {
+		gitOutput = func(args ...string) (string, error) {
+			return "", fmt.Errorf("git describe: %w: %s", &exec.ExitError{}, msg)
+		}
+		if ok, _, err := checkTaggedBuild(&BuildInfo{VCSRevision: "abc"}); ok || err != nil {
+			t.Errorf("Untagged revision (%s): got %v, %v; want false, nil", msg, ok, err)
+		}
+	}
+
+	gitOutput = func(args ...string) (string, error) {
+		return "", fmt.Errorf("git describe: %w: %s", &exec.ExitError{}, "fatal: not a git repository")
+	}
+	if _, _, err := checkTaggedBuild(&BuildInfo{VCSRevision: "abc"}); err == nil || !strings.Contains(err.Error(), "not a git repository") {
+		t.Errorf("Git failure: got error %v, want it reported with stderr", err)
+	}
+
+	gitOutput = func(args ...string) (string, error) {
+		return "", exec.ErrNotFound
+	}
+	if _, _, err := checkTaggedBuild(&BuildInfo{VCSRevision: "abc"}); !errors.Is(err, exec.ErrNotFound) {
+		t.Errorf("Missing git: got error %v, want exec.ErrNotFound", err)
+	}
+}
+
+func TestNearestTag(t *testing.T) {
+	// Depends on git and the checkout; only ensure it doesn't panic
+	tag, err := NearestTag()
+	t.Logf("NearestTag() = %q, %v", tag, err)
+}
@@ -0,0 +1,26 @@
+package release
+
+import (
+	"fmt"
+	"time"
+)
+
+// AddTimed adds a condition that fails when the check returns false or an
+// error, or when it takes longer than maxDuration, e.g. for "this
+// dependency must respond within 500ms". The check always runs to
+// completion, so the result's Duration is the actual time taken, and the
+// detail compares it with the limit
+func (cs *ConditionSet) AddTimed(name, description string, maxDuration time.Duration, check func() (bool, error)) {
+	cs.AddDetailed(name, description, func() (bool, string, error) {
+		start := now()
+		passed, err := check()
+		elapsed := now().Sub(start)
+		if err != nil {
+			return false, "", err
+		}
+		if elapsed > maxDuration {
+			return false, fmt.Sprintf("took %s, exceeding the %s limit", elapsed, maxDuration), nil
+		}
+		return passed, fmt.Sprintf("took %s of %s allowed", elapsed, maxDuration), nil
+	})
+}
@@ -0,0 +1,59 @@
+package release
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAddTimed(t *testing.T) {
+	// Every call to now advances the clock by step
+	var clock time.Time
+	var step time.Duration
+	now = func() time.Time {
+		clock = clock.Add(step)
+		return clock
+	}
+	defer func() { now = time.Now }()
+
+	tests := []struct {
+		name       string
+		step       time.Duration
+		passed     bool
+		err        error
+		wantPassed bool
+		wantDetail string
+	}{
+		{"fast pass", 100 * time.Millisecond, true, nil, true, "took 100ms of 500ms allowed"},
+		{"slow pass", time.Second, true, nil, false, "took 1s, exceeding the 500ms limit"},
+		{"fast fail", 100 * time.Millisecond, false, nil, false, "took 100ms of 500ms allowed"},
+		{"error", 100 * time.Millisecond, true, errors.New("refused"), false, ""},
+	}
+	for _, tt := range tests {
+		step = tt.step
+		cs := NewConditionSet()
+		cs.AddTimed("dep", "Dependency responds quickly", 500*time.Millisecond, func() (bool, error) {
+			return tt.passed, tt.err
+		})
+		r := cs.TestAll()[0]
+		if r.Passed != tt.wantPassed || r.Detail != tt.wantDetail || r.Error != tt.err {
+			t.Errorf("%s: got %+v, want passed=%v detail=%q", tt.name, r, tt.wantPassed, tt.wantDetail)
+		}
+		if r.Duration <= 0 {
+			t.Errorf("%s: Duration should be recorded, got %s", tt.name, r.Duration)
+		}
+	}
+}
+
+func TestAddTimedRealClock(t *testing.T) {
+	cs := NewConditionSet()
+	cs.AddTimed("sleepy", "Sleeps", time.Millisecond, func() (bool, error) {
+		time.Sleep(20 * time.Millisecond)
+		return true, nil
+	})
+	r := cs.TestAll()[0]
+	if r.Passed || !strings.Contains(r.Detail, "exceeding the 1ms limit") || r.Duration < 20*time.Millisecond {
+		t.Errorf("Slow check should fail with its actual duration: %+v", r)
+	}
+}
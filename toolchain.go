@@ -0,0 +1,44 @@
+package release
+
+import (
+	"runtime"
+	"runtime/debug"
+)
+
+// EffectiveBuildGoVersion returns the version of the Go toolchain that
+// built the binary, as recorded in its build info, e.g. "go1.22.3". ok is
+// false when the binary has no build info, in which case runtime.Version
+// is returned.
+//
+// Since Go 1.21, the toolchain directive in go.mod (with GOTOOLCHAIN=auto)
+// can make the installed go command download and run a different
+// toolchain, so the version that built a binary need not be the one on
+// the build machine's PATH. For the running binary the recorded version
+// and runtime.Version agree, since the runtime is linked from the same
+// toolchain; the recorded version is the provenance statement to check
+// against, and the one reported for binaries built elsewhere
+func EffectiveBuildGoVersion() (version string, ok bool) {
+	info, ok := debug.ReadBuildInfo()
+	if !ok || info.GoVersion == "" {
+		return runtime.Version(), false
+	}
+	return info.GoVersion, true
+}
+
+// CompareBuildGoVersion compares the build toolchain version (see
+// EffectiveBuildGoVersion) with a target version, like CompareGoVersion
+// does for the runtime version
+func CompareBuildGoVersion(targetVersion string) (Ordering, error) {
+	version, _ := EffectiveBuildGoVersion()
+	return compareVersions(version, targetVersion)
+}
+
+// IsBuildGoVersionAtLeast checks whether the binary was built by at least
+// the given Go toolchain version, for provenance checks
+func IsBuildGoVersionAtLeast(minVersion string) (bool, error) {
+	cmp, err := CompareBuildGoVersion(minVersion)
+	if err != nil {
+		return false, err
+	}
+	return !cmp.IsLess(), nil
+}
@@ -0,0 +1,28 @@
+package release
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestEffectiveBuildGoVersion(t *testing.T) {
+	version, ok := EffectiveBuildGoVersion()
+	if !ok {
+		t.Skip("no build info available")
+	}
+	// The runtime is linked from the toolchain that built the binary
+	if version != runtime.Version() {
+		t.Errorf("EffectiveBuildGoVersion() = %s, runtime.Version() = %s", version, runtime.Version())
+	}
+
+	if ok, err := IsBuildGoVersionAtLeast("1.10"); !ok || err != nil {
+		t.Errorf("IsBuildGoVersionAtLeast(1.10) = %v, %v, want true", ok, err)
+	}
+	cmp, err := CompareBuildGoVersion("99.0")
+	if err != nil || !cmp.IsLess() {
+		t.Errorf("CompareBuildGoVersion(99.0) = %v, %v, want Less", cmp, err)
+	}
+	if _, err := CompareBuildGoVersion("invalid"); err == nil {
+		t.Error("CompareBuildGoVersion(invalid) should fail")
+	}
+}
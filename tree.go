@@ -0,0 +1,185 @@
+package release
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// AddGroup adds group as a single condition that passes when every
+// condition of group is OK, keeping the hierarchy that AddConditionSet
+// flattens. Flat runners such as TestAll report the group as one result
+// whose detail names the failed conditions; TestAllTree reports its
+// conditions as children. Conditions skipped in group stay skipped
+func (cs *ConditionSet) AddGroup(name, description string, group *ConditionSet) {
+	cond := newContextCondition(name, description, func(ctx context.Context) (bool, string, error) {
+		ok, detail := summarizeGroup(group.evaluateGroup(ctx))
+		return ok, detail, nil
+	})
+	cond.group = group
+	cs.conditions = append(cs.conditions, cond)
+}
+
+// evaluateGroup runs every condition of a nested set within the run of
+// ctx, so memoized probes are shared with the parent set
+func (cs *ConditionSet) evaluateGroup(ctx context.Context) TestResults {
+	skips := cs.skipReasons()
+	results := make(TestResults, 0, len(cs.conditions))
+	for _, cond := range cs.conditions {
+		results = append(results, evaluateUnlessSkipped(ctx, cond, skips))
+	}
+	return results
+}
+
+// summarizeGroup derives the outcome of a group from its results
+func summarizeGroup(results TestResults) (bool, string) {
+	failed := results.FailedResults()
+	if len(failed) > 0 {
+		return false, fmt.Sprintf("%d of %d failed: %s", len(failed), len(results), strings.Join(failed.names(), ", "))
+	}
+	return true, fmt.Sprintf("all %d passed", len(results))
+}
+
+// TestNode is the result of one condition in a TestTree. The result of a
+// group (see AddGroup) holds the results of its conditions as children
+type TestNode struct {
+	TestResult
+	Children TestTree
+}
+
+// TestTree is the hierarchical result of a run, in the order the
+// conditions were added
+type TestTree []TestNode
+
+// TestAllTree tests all conditions in the set like TestAll, but reports
+// the conditions of groups as children of the group's result, so a
+// failing condition is shown under its parent subsystem
+func (cs *ConditionSet) TestAllTree() TestTree {
+	skips := cs.skipReasons()
+	return cs.testTree(beginRun(), skips)
+}
+
+// testTree evaluates the set within the run of ctx, descending into groups
+func (cs *ConditionSet) testTree(ctx context.Context, skips map[string]string) TestTree {
+	tree := make(TestTree, 0, len(cs.conditions))
+	for _, cond := range cs.conditions {
+		if _, skipped := skips[cond.Name]; skipped || cond.group == nil {
+			tree = append(tree, TestNode{TestResult: evaluateUnlessSkipped(ctx, cond, skips)})
+			continue
+		}
+
+		// Evaluate the group's conditions once, as children, and derive
+		// the group's own result from them
+		start := now()
+		children := cond.group.testTree(ctx, cond.group.skipReasons())
+		result := TestResult{
+			Name:        cond.Name,
+			Description: cond.Description,
+			ErrorIsPass: cond.ErrorIsPass,
+			StartedAt:   start,
+			Duration:    now().Sub(start),
+			ExitCode:    cond.ExitCode,
+		}
+		result.Passed, result.Detail = summarizeGroup(children.Results())
+		tree = append(tree, TestNode{TestResult: result, Children: children})
+	}
+	return tree
+}
+
+// Results returns the top-level results of the tree, in order
+func (tree TestTree) Results() TestResults {
+	results := make(TestResults, len(tree))
+	for i, node := range tree {
+		results[i] = node.TestResult
+	}
+	return results
+}
+
+// AllPassed reports whether every top-level result is OK. A group is OK
+// only when all of its children are
+func (tree TestTree) AllPassed() bool {
+	return tree.Results().AllPassed()
+}
+
+// WriteText writes the tree as an indented list, two spaces per level,
+// followed by a summary line for the top-level results
+func (tree TestTree) WriteText(w io.Writer, opts ReportOptions) error {
+	var b strings.Builder
+	tree.writeText(&b, opts.symbolsFor(w), 0)
+	fmt.Fprintln(&b, tree.Results().summaryLine(opts))
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func (tree TestTree) writeText(b *strings.Builder, symbols Symbols, depth int) {
+	indent := strings.Repeat("  ", depth)
+	for _, node := range tree {
+		r := node.TestResult
+		fmt.Fprintf(b, "%s%s %s", indent, symbols.symbol(r), r.Name)
+		if r.Description != "" {
+			fmt.Fprintf(b, ": %s", r.Description)
+		}
+		b.WriteString("\n")
+		if r.Skipped {
+			fmt.Fprintf(b, "%s    %s\n", indent, r.SkipReason)
+		}
+		if r.Detail != "" && node.Children == nil {
+			fmt.Fprintf(b, "%s    %s\n", indent, r.Detail)
+		}
+		if r.Error != nil {
+			fmt.Fprintf(b, "%s    error: %v\n", indent, r.Error)
+		}
+		node.Children.writeText(b, symbols, depth+1)
+	}
+}
+
+// testNodeJSON is the serialized form of a TestNode
+type testNodeJSON struct {
+	testResultJSON
+	Children TestTree `json:"children,omitempty"`
+}
+
+// MarshalJSON encodes the node as its result with a nested "children"
+// array for groups
+func (n TestNode) MarshalJSON() ([]byte, error) {
+	return json.Marshal(testNodeJSON{newTestResultJSON(n.TestResult), n.Children})
+}
+
+// UnmarshalJSON decodes a node encoded by MarshalJSON
+func (n *TestNode) UnmarshalJSON(data []byte) error {
+	var v testNodeJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	result, err := v.result()
+	if err != nil {
+		return err
+	}
+	*n = TestNode{TestResult: result, Children: v.Children}
+	return nil
+}
+
+// jsonTreeReport is the document written by TestTree.WriteJSON
+type jsonTreeReport struct {
+	SchemaVersion string   `json:"schema_version"`
+	AllPassed     bool     `json:"all_passed"`
+	Results       TestTree `json:"results"`
+}
+
+// WriteJSON writes the tree and the overall verdict as an indented JSON
+// document, with the results of a group's conditions nested under its
+// "children"
+func (tree TestTree) WriteJSON(w io.Writer) error {
+	if tree == nil {
+		tree = TestTree{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(jsonTreeReport{
+		SchemaVersion: SchemaVersion,
+		AllPassed:     tree.AllPassed(),
+		Results:       tree,
+	})
+}
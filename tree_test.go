@@ -0,0 +1,134 @@
+package release
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// treeFixture returns a set with a top-level condition and a "network"
+// group whose "cache" condition fails
+func treeFixture() *ConditionSet {
+	network := NewConditionSet()
+	network.Add("db", "Database reachable", func() (bool, error) { return true, nil })
+	network.AddDetailed("cache", "Cache reachable", func() (bool, string, error) { return false, "connection refused", nil })
+	network.Add("dns", "DNS resolves", func() (bool, error) { return false, errors.New("unused") })
+	network.SkipChecks("dns")
+
+	cs := NewConditionSet()
+	cs.Add("go-version", "Go 1.21+", func() (bool, error) { return true, nil })
+	cs.AddGroup("network", "Network dependencies", network)
+	return cs
+}
+
+func TestAddGroup(t *testing.T) {
+	results := treeFixture().TestAll()
+	if len(results) != 2 {
+		t.Fatalf("TestAll() returned %d results, want 2", len(results))
+	}
+	group := results[1]
+	if group.Name != "network" || group.Passed || group.Error != nil || group.Detail != "1 of 3 failed: cache" {
+		t.Errorf("group result = %+v", group)
+	}
+
+	passing := NewConditionSet()
+	passing.Add("db", "", func() (bool, error) { return true, nil })
+	cs := NewConditionSet()
+	cs.AddGroup("network", "", passing)
+	if r := cs.TestAll()[0]; !r.Passed || r.Detail != "all 1 passed" {
+		t.Errorf("passing group result = %+v", r)
+	}
+}
+
+func TestAllTree(t *testing.T) {
+	tree := treeFixture().TestAllTree()
+	if len(tree) != 2 {
+		t.Fatalf("TestAllTree() returned %d nodes, want 2", len(tree))
+	}
+	if tree[0].Name != "go-version" || !tree[0].Passed || tree[0].Children != nil {
+		t.Errorf("leaf node = %+v", tree[0])
+	}
+
+	group := tree[1]
+	if group.Passed || group.Detail != "1 of 3 failed: cache" {
+		t.Errorf("group node = %+v", group.TestResult)
+	}
+	var names []string
+	for _, child := range group.Children {
+		names = append(names, child.Name)
+	}
+	if strings.Join(names, ",") != "db,cache,dns" {
+		t.Errorf("group children = %v", names)
+	}
+	if !group.Children[2].Skipped {
+		t.Error("Conditions skipped in the group should stay skipped")
+	}
+	if tree.AllPassed() {
+		t.Error("AllPassed() = true with a failing group")
+	}
+	if got := tree.Results(); len(got) != 2 || got[1].Name != "network" {
+		t.Errorf("Results() = %+v", got)
+	}
+}
+
+func TestAllTreeSkippedGroup(t *testing.T) {
+	cs := treeFixture()
+	cs.SkipChecks("network")
+	tree := cs.TestAllTree()
+	if !tree[1].Skipped || tree[1].Children != nil {
+		t.Errorf("skipped group node = %+v", tree[1])
+	}
+}
+
+func TestTreeWriteText(t *testing.T) {
+	var buf bytes.Buffer
+	if err := treeFixture().TestAllTree().WriteText(&buf, ReportOptions{Symbols: ASCIISymbols}); err != nil {
+		t.Fatal(err)
+	}
+	want := "" +
+		"[PASS] go-version: Go 1.21+\n" +
+		"[FAIL] network: Network dependencies\n" +
+		"  [PASS] db: Database reachable\n" +
+		"  [FAIL] cache: Cache reachable\n" +
+		"      connection refused\n" +
+		"  [SKIP] dns: DNS resolves\n" +
+		"      skipped via SkipChecks\n"
+	if got := buf.String(); !strings.HasPrefix(got, want) || !strings.Contains(got, "1/2 conditions passed") {
+		t.Errorf("WriteText() =\n%s\nwant prefix\n%s", got, want)
+	}
+}
+
+func TestTreeJSON(t *testing.T) {
+	tree := treeFixture().TestAllTree()
+
+	var buf bytes.Buffer
+	if err := tree.WriteJSON(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var report struct {
+		SchemaVersion string   `json:"schema_version"`
+		AllPassed     bool     `json:"all_passed"`
+		Results       TestTree `json:"results"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatal(err)
+	}
+	if report.SchemaVersion != SchemaVersion || report.AllPassed {
+		t.Errorf("report = %+v", report)
+	}
+	decoded := report.Results
+	if len(decoded) != 2 || len(decoded[1].Children) != 3 {
+		t.Fatalf("decoded tree = %+v", decoded)
+	}
+	if c := decoded[1].Children[1]; c.Name != "cache" || c.Passed || c.Detail != "connection refused" {
+		t.Errorf("decoded child = %+v", c.TestResult)
+	}
+	if decoded[0].Children != nil {
+		t.Errorf("leaf should have no children, got %+v", decoded[0].Children)
+	}
+	if !strings.Contains(buf.String(), `"children"`) {
+		t.Errorf("WriteJSON() should nest children:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,36 @@
+package release
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// RLimitInfinity is the value reported for an unlimited resource limit
+const RLimitInfinity = ^uint64(0)
+
+// formatLimit renders a resource limit, spelling out "unlimited"
+func formatLimit(limit uint64) string {
+	if limit == RLimitInfinity {
+		return "unlimited"
+	}
+	return strconv.FormatUint(limit, 10)
+}
+
+// StackSizeLimit returns the soft and hard RLIMIT_STACK limits in bytes.
+// Unlimited values are reported as RLimitInfinity. It returns
+// ErrNotSupported on non-Unix platforms
+func StackSizeLimit() (soft, hard uint64, err error) {
+	return getrlimit(rlimitStack)
+}
+
+// MinStackSizeCondition returns a condition that fails when the soft
+// stack size limit is below the given number of bytes
+func MinStackSizeCondition(bytes uint64) Condition {
+	return newDetailedCondition("min-stack-size", fmt.Sprintf("Stack size limit is at least %d bytes", bytes), func() (bool, string, error) {
+		soft, _, err := StackSizeLimit()
+		if err != nil {
+			return false, "", err
+		}
+		return soft >= bytes, fmt.Sprintf("stack size limit is %s bytes, need %d", formatLimit(soft), bytes), nil
+	})
+}
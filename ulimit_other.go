@@ -0,0 +1,9 @@
+//go:build !unix
+
+package release
+
+const rlimitStack = 0
+
+func getrlimit(resource int) (soft, hard uint64, err error) {
+	return 0, 0, ErrNotSupported
+}
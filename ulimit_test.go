@@ -0,0 +1,39 @@
+package release
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestFormatLimit(t *testing.T) {
+	if formatLimit(RLimitInfinity) != "unlimited" {
+		t.Error("RLimitInfinity should format as unlimited")
+	}
+	if formatLimit(8388608) != "8388608" {
+		t.Errorf("formatLimit(8388608) = %s", formatLimit(8388608))
+	}
+}
+
+func TestStackSizeLimit(t *testing.T) {
+	soft, hard, err := StackSizeLimit()
+	if errors.Is(err, ErrNotSupported) {
+		t.Skip("stack size limit not supported on this platform")
+	}
+	if err != nil {
+		t.Fatalf("StackSizeLimit() error = %v", err)
+	}
+	if soft > hard {
+		t.Errorf("soft limit %d exceeds hard limit %d", soft, hard)
+	}
+	t.Logf("Stack size limit: soft=%s hard=%s", formatLimit(soft), formatLimit(hard))
+
+	if r := MinStackSizeCondition(1).evaluate(); !r.Passed || !strings.Contains(r.Detail, "stack size limit is") {
+		t.Errorf("MinStackSizeCondition(1) = %+v", r)
+	}
+	if soft != RLimitInfinity {
+		if r := MinStackSizeCondition(soft + 1).evaluate(); r.Passed {
+			t.Errorf("MinStackSizeCondition above the soft limit should fail: %+v", r)
+		}
+	}
+}
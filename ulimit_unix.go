@@ -0,0 +1,24 @@
+//go:build unix
+
+package release
+
+import "golang.org/x/sys/unix"
+
+const rlimitStack = unix.RLIMIT_STACK
+
+// getrlimit returns the soft and hard limits of a resource, normalizing
+// the platform's infinity value to RLimitInfinity
+func getrlimit(resource int) (soft, hard uint64, err error) {
+	var rl unix.Rlimit
+	if err := unix.Getrlimit(resource, &rl); err != nil {
+		return 0, 0, err
+	}
+	return normalizeRlimit(uint64(rl.Cur)), normalizeRlimit(uint64(rl.Max)), nil
+}
+
+func normalizeRlimit(v uint64) uint64 {
+	if v == uint64(unix.RLIM_INFINITY) {
+		return RLimitInfinity
+	}
+	return v
+}
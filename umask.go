@@ -0,0 +1,58 @@
+package release
+
+import (
+	"bufio"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// procSelfStatusPath holds the process status, including its umask on
+// Linux 4.7 and later
+var procSelfStatusPath = "/proc/self/status"
+
+// CurrentUmask returns the process's file mode creation mask, e.g. 0o022.
+// On Linux it is read from /proc/self/status without changing it. Other
+// Unix platforms, and older kernels, set and immediately restore the
+// umask; files created concurrently by other goroutines in that window
+// get a 0o077 mask. It returns ErrNotSupported on non-Unix platforms
+func CurrentUmask() (int, error) {
+	return currentUmask()
+}
+
+// MaxUmaskCondition returns a condition that fails when the umask is more
+// permissive than mask, i.e. when it does not mask every permission bit
+// mask does. With mask 0o027, files are never group-writable or
+// accessible to others
+func MaxUmaskCondition(mask int) Condition {
+	return newDetailedCondition("max-umask", fmt.Sprintf("Umask is at least as restrictive as %04o", mask), func() (bool, string, error) {
+		current, err := CurrentUmask()
+		if err != nil {
+			return false, "", err
+		}
+		ok, detail := checkUmask(current, mask)
+		return ok, detail, nil
+	})
+}
+
+// checkUmask applies the MaxUmaskCondition threshold
+func checkUmask(current, mask int) (bool, string) {
+	return current&mask == mask, fmt.Sprintf("umask is %04o, need at least %04o", current, mask)
+}
+
+// statusUmask returns the "Umask:" field of /proc/self/status contents
+func statusUmask(status string) (int, bool) {
+	scanner := bufio.NewScanner(strings.NewReader(status))
+	for scanner.Scan() {
+		name, value, ok := strings.Cut(scanner.Text(), ":")
+		if !ok || name != "Umask" {
+			continue
+		}
+		mask, err := strconv.ParseUint(strings.TrimSpace(value), 8, 32)
+		if err != nil {
+			return 0, false
+		}
+		return int(mask), true
+	}
+	return 0, false
+}
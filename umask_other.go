@@ -0,0 +1,7 @@
+//go:build !unix
+
+package release
+
+func currentUmask() (int, error) {
+	return 0, ErrNotSupported
+}
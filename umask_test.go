@@ -0,0 +1,83 @@
+package release
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestStatusUmask(t *testing.T) {
+	tests := []struct {
+		status string
+		want   int
+		wantOK bool
+	}{
+		{"Name:\tgo\nUmask:\t0022\nState:\tR (running)\n", 0o022, true},
+		{"Name:\tgo\nUmask:\t0077\n", 0o077, true},
+		{"Name:\tgo\nState:\tR (running)\n", 0, false},
+		{"Umask:\tnope\n", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := statusUmask(tt.status)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("statusUmask(%q) = %04o, %v; want %04o, %v", tt.status, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestCheckUmask(t *testing.T) {
+	tests := []struct {
+		current, mask int
+		want          bool
+	}{
+		{0o027, 0o027, true},
+		{0o077, 0o027, true},
+		{0o022, 0o027, false},
+		{0o002, 0o022, false},
+		{0o000, 0o000, true},
+	}
+	for _, tt := range tests {
+		if got, detail := checkUmask(tt.current, tt.mask); got != tt.want {
+			t.Errorf("checkUmask(%04o, %04o) = %v (%s), want %v", tt.current, tt.mask, got, detail, tt.want)
+		}
+	}
+}
+
+func TestCurrentUmask(t *testing.T) {
+	before, err := CurrentUmask()
+	if errors.Is(err, ErrNotSupported) {
+		if r := MaxUmaskCondition(0o022).evaluate(); !errors.Is(r.Error, ErrNotSupported) {
+			t.Errorf("Expected ErrNotSupported, got %+v", r)
+		}
+		return
+	}
+	if err != nil {
+		t.Fatalf("CurrentUmask() error = %v", err)
+	}
+
+	// Force the set-and-restore fallback and check it leaves the umask as is
+	orig := procSelfStatusPath
+	defer func() { procSelfStatusPath = orig }()
+	procSelfStatusPath = filepath.Join(t.TempDir(), "missing")
+	fallback, err := CurrentUmask()
+	if err != nil || fallback != before {
+		t.Errorf("Fallback CurrentUmask() = %04o, %v; want %04o", fallback, err, before)
+	}
+	if after, _ := CurrentUmask(); after != before {
+		t.Errorf("CurrentUmask changed the umask from %04o to %04o", before, after)
+	}
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "f")
+	if err := os.WriteFile(path, nil, 0o666); err != nil {
+		t.Fatal(err)
+	}
+	if info, err := os.Stat(path); err == nil && int(info.Mode().Perm()) != 0o666&^before {
+		t.Errorf("File created with mode %04o, want %04o for umask %04o", info.Mode().Perm(), 0o666&^before, before)
+	}
+
+	if r := MaxUmaskCondition(before).evaluate(); !r.Passed {
+		t.Errorf("MaxUmaskCondition(%04o) should pass: %+v", before, r)
+	}
+}
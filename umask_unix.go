@@ -0,0 +1,28 @@
+//go:build unix
+
+package release
+
+import (
+	"os"
+	"sync"
+
+	"golang.org/x/sys/unix"
+)
+
+// umaskMu serializes the set-and-restore fallback of currentUmask
+var umaskMu sync.Mutex
+
+func currentUmask() (int, error) {
+	if data, err := os.ReadFile(procSelfStatusPath); err == nil {
+		if mask, ok := statusUmask(string(data)); ok {
+			return mask, nil
+		}
+	}
+
+	// Setting the umask is the only portable way to read it
+	umaskMu.Lock()
+	defer umaskMu.Unlock()
+	mask := unix.Umask(0o077)
+	unix.Umask(mask)
+	return mask, nil
+}
@@ -0,0 +1,21 @@
+package release
+
+import "fmt"
+
+// RequireVCSInfoCondition returns a condition that fails when the build
+// has no embedded vcs.revision (see HasVCSInfo), e.g. when it was built
+// outside a VCS checkout or with -buildvcs=false. It does not check
+// whether the tree was clean; see BuildPolicy.RequireCleanVCS for that
+func RequireVCSInfoCondition() Condition {
+	return newDetailedCondition("vcs-info", "Build has embedded VCS information", func() (bool, string, error) {
+		return checkVCSInfo(GetBuildInfo())
+	})
+}
+
+// checkVCSInfo evaluates whether info carries a VCS revision
+func checkVCSInfo(info *BuildInfo) (bool, string, error) {
+	if info.VCSRevision == "" {
+		return false, "no vcs.revision embedded in the build", nil
+	}
+	return true, fmt.Sprintf("built from revision %s", info.VCSRevision), nil
+}
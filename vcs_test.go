@@ -0,0 +1,17 @@
+package release
+
+import "testing"
+
+func TestRequireVCSInfoCondition(t *testing.T) {
+	if ok, detail, _ := checkVCSInfo(&BuildInfo{}); ok || detail == "" {
+		t.Errorf("Missing revision: got %v, %q; want false with a detail", ok, detail)
+	}
+	if ok, _, _ := checkVCSInfo(&BuildInfo{VCSRevision: "abc", VCSModified: true}); !ok {
+		t.Error("A dirty build with a revision should still pass")
+	}
+
+	passed, err := RequireVCSInfoCondition().Check()
+	if err != nil || passed != HasVCSInfo() {
+		t.Errorf("Check() = %v, %v; want %v, nil", passed, err, HasVCSInfo())
+	}
+}
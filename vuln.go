@@ -0,0 +1,150 @@
+package release
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// ToolchainVulnerability is a published vulnerability in the Go toolchain
+// or standard library
+type ToolchainVulnerability struct {
+	// ID identifies the vulnerability, e.g. "CVE-2023-45288"
+	ID string
+	// Fixed lists the first fixed release of each affected minor line,
+	// e.g. ["1.21.9", "1.22.2"]. Releases on those lines before the fix
+	// are affected, as are releases on older minor lines
+	Fixed []string
+}
+
+// defaultToolchainVulnerabilities holds vulnerabilities fixed in Go
+// security releases. Add entries as security releases are announced
+var defaultToolchainVulnerabilities = []ToolchainVulnerability{
+	{ID: "CVE-2023-29403", Fixed: []string{"1.19.10", "1.20.5"}},
+	{ID: "CVE-2023-39325", Fixed: []string{"1.20.10", "1.21.3"}},
+	{ID: "CVE-2023-45288", Fixed: []string{"1.21.9", "1.22.2"}},
+	{ID: "CVE-2024-24790", Fixed: []string{"1.21.11", "1.22.4"}},
+	{ID: "CVE-2024-24791", Fixed: []string{"1.21.12", "1.22.5"}},
+	{ID: "CVE-2024-34156", Fixed: []string{"1.22.7", "1.23.1"}},
+	{ID: "CVE-2024-45336", Fixed: []string{"1.22.11", "1.23.5"}},
+	{ID: "CVE-2025-22871", Fixed: []string{"1.23.8", "1.24.2"}},
+}
+
+// parsedVulnerability is a ToolchainVulnerability with parsed versions
+type parsedVulnerability struct {
+	id    string
+	fixed []GoVersion
+}
+
+// toolchainVulns is the vulnerability table in use
+var (
+	toolchainVulnsMu sync.RWMutex
+	toolchainVulns   = mustParseVulnerabilities(defaultToolchainVulnerabilities)
+)
+
+// SetToolchainVulnerabilities replaces the table of known toolchain
+// vulnerabilities, e.g. to add ones announced after the library was
+// released or to track a security team's own list. A nil table restores
+// the embedded one
+func SetToolchainVulnerabilities(vulns []ToolchainVulnerability) error {
+	if vulns == nil {
+		vulns = defaultToolchainVulnerabilities
+	}
+	parsed, err := parseVulnerabilities(vulns)
+	if err != nil {
+		return err
+	}
+
+	toolchainVulnsMu.Lock()
+	defer toolchainVulnsMu.Unlock()
+	toolchainVulns = parsed
+	return nil
+}
+
+// HasKnownVulnerableToolchain reports whether the running Go version is
+// affected by a known vulnerability (see SetToolchainVulnerabilities),
+// returning the IDs of those that apply
+func HasKnownVulnerableToolchain() (bool, []string, error) {
+	ids, err := toolchainVulnerabilities(runtime.Version())
+	return len(ids) > 0, ids, err
+}
+
+// SecureToolchainCondition returns a condition that fails when the
+// running Go version is affected by a known vulnerability, listing the
+// IDs that apply (see HasKnownVulnerableToolchain)
+func SecureToolchainCondition() Condition {
+	return newDetailedCondition("secure-toolchain", "Go version has no known vulnerabilities", func() (bool, string, error) {
+		version := runtime.Version()
+		ids, err := toolchainVulnerabilities(version)
+		if err != nil {
+			return false, "", err
+		}
+		if len(ids) > 0 {
+			return false, fmt.Sprintf("%s is affected by %s", version, strings.Join(ids, ", ")), nil
+		}
+		return true, fmt.Sprintf("no known vulnerabilities in %s", version), nil
+	})
+}
+
+// toolchainVulnerabilities returns the IDs of the vulnerabilities in the
+// table that affect version
+func toolchainVulnerabilities(version string) ([]string, error) {
+	v, err := ParseGoVersion(version)
+	if err != nil {
+		return nil, err
+	}
+
+	toolchainVulnsMu.RLock()
+	defer toolchainVulnsMu.RUnlock()
+	var ids []string
+	for _, vuln := range toolchainVulns {
+		if vuln.affects(v) {
+			ids = append(ids, vuln.id)
+		}
+	}
+	return ids, nil
+}
+
+// affects reports whether v is before the fix on its minor line, or on a
+// minor line older than every fixed one
+func (p parsedVulnerability) affects(v GoVersion) bool {
+	older := true
+	for _, fixed := range p.fixed {
+		if fixed.Major == v.Major && fixed.Minor == v.Minor {
+			return v.Compare(fixed) < 0
+		}
+		if fixed.Major < v.Major || fixed.Major == v.Major && fixed.Minor < v.Minor {
+			older = false
+		}
+	}
+	return older
+}
+
+// parseVulnerabilities validates a vulnerability table
+func parseVulnerabilities(vulns []ToolchainVulnerability) ([]parsedVulnerability, error) {
+	parsed := make([]parsedVulnerability, 0, len(vulns))
+	for _, vuln := range vulns {
+		if vuln.ID == "" || len(vuln.Fixed) == 0 {
+			return nil, fmt.Errorf("vulnerability table: entry %q needs an ID and fixed versions", vuln.ID)
+		}
+		p := parsedVulnerability{id: vuln.ID}
+		for _, fixed := range vuln.Fixed {
+			v, err := ParseGoVersion(fixed)
+			if err != nil {
+				return nil, fmt.Errorf("vulnerability table: %s: %w", vuln.ID, err)
+			}
+			p.fixed = append(p.fixed, v)
+		}
+		parsed = append(parsed, p)
+	}
+	return parsed, nil
+}
+
+func mustParseVulnerabilities(vulns []ToolchainVulnerability) []parsedVulnerability {
+	parsed, err := parseVulnerabilities(vulns)
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}
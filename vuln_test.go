@@ -0,0 +1,94 @@
+package release
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestToolchainVulnerabilities(t *testing.T) {
+	defer SetToolchainVulnerabilities(nil)
+
+	err := SetToolchainVulnerabilities([]ToolchainVulnerability{
+		{ID: "CVE-A", Fixed: []string{"1.21.9", "1.22.2"}},
+		{ID: "CVE-B", Fixed: []string{"1.22.7", "1.23.1"}},
+	})
+	if err != nil {
+		t.Fatalf("SetToolchainVulnerabilities() error = %v", err)
+	}
+
+	tests := []struct {
+		version string
+		want    []string
+	}{
+		{"go1.20.14", []string{"CVE-A", "CVE-B"}},
+		{"go1.21.8", []string{"CVE-A", "CVE-B"}},
+		{"go1.21.9", []string{"CVE-B"}},
+		{"go1.22", []string{"CVE-A", "CVE-B"}},
+		{"go1.22.2", []string{"CVE-B"}},
+		{"go1.22.7", nil},
+		{"go1.23rc1", []string{"CVE-B"}},
+		{"go1.23.1 X:boringcrypto", nil},
+		{"go1.24.0", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			got, err := toolchainVulnerabilities(tt.version)
+			if err != nil {
+				t.Fatalf("toolchainVulnerabilities() error = %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("toolchainVulnerabilities(%q) = %v, want %v", tt.version, got, tt.want)
+			}
+		})
+	}
+
+	if _, err := toolchainVulnerabilities("devel +abc123"); err == nil {
+		t.Error("toolchainVulnerabilities() should reject unparseable versions")
+	}
+}
+
+func TestSetToolchainVulnerabilities(t *testing.T) {
+	defer SetToolchainVulnerabilities(nil)
+
+	invalid := [][]ToolchainVulnerability{
+		{{ID: "", Fixed: []string{"1.22.2"}}},
+		{{ID: "CVE-A"}},
+		{{ID: "CVE-A", Fixed: []string{"latest"}}},
+	}
+	for _, vulns := range invalid {
+		if err := SetToolchainVulnerabilities(vulns); err == nil {
+			t.Errorf("SetToolchainVulnerabilities(%v) should fail", vulns)
+		}
+	}
+
+	SetToolchainVulnerabilities([]ToolchainVulnerability{})
+	if vulnerable, ids, err := HasKnownVulnerableToolchain(); vulnerable || ids != nil || err != nil {
+		t.Errorf("HasKnownVulnerableToolchain() with an empty table = %v, %v, %v", vulnerable, ids, err)
+	}
+
+	SetToolchainVulnerabilities(nil)
+	if ids, _ := toolchainVulnerabilities("go1.21.0"); !slices.Contains(ids, "CVE-2023-45288") {
+		t.Errorf("SetToolchainVulnerabilities(nil) should restore the embedded table, got %v", ids)
+	}
+}
+
+func TestSecureToolchainCondition(t *testing.T) {
+	defer SetToolchainVulnerabilities(nil)
+
+	// Every released version is on a line older than 1.999
+	SetToolchainVulnerabilities([]ToolchainVulnerability{{ID: "CVE-TEST", Fixed: []string{"1.999.1"}}})
+	r := SecureToolchainCondition().evaluate()
+	if r.Error != nil {
+		t.Skipf("running version is not parseable: %v", r.Error)
+	}
+	if r.Passed || !strings.Contains(r.Detail, "CVE-TEST") {
+		t.Errorf("SecureToolchainCondition() = %+v, want failure naming CVE-TEST", r)
+	}
+
+	SetToolchainVulnerabilities([]ToolchainVulnerability{})
+	if r := SecureToolchainCondition().evaluate(); !r.Passed {
+		t.Errorf("SecureToolchainCondition() with an empty table = %+v, want pass", r)
+	}
+}
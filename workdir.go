@@ -0,0 +1,42 @@
+package release
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// CurrentWorkingDir returns the process's working directory
+func CurrentWorkingDir() (string, error) {
+	return os.Getwd()
+}
+
+// WorkingDirCondition returns a condition that fails unless the working
+// directory is expectedPrefix or inside it, reporting the actual working
+// directory. The comparison is per path element, so "/srv/app" does not
+// match "/srv/application"
+func WorkingDirCondition(expectedPrefix string) Condition {
+	return newDetailedCondition("working-dir", fmt.Sprintf("Working directory is under %s", expectedPrefix), func() (bool, string, error) {
+		cwd, err := CurrentWorkingDir()
+		if err != nil {
+			return false, "", err
+		}
+		if !hasPathPrefix(cwd, expectedPrefix) {
+			return false, fmt.Sprintf("working directory is %s, want under %s", cwd, expectedPrefix), nil
+		}
+		return true, fmt.Sprintf("working directory is %s", cwd), nil
+	})
+}
+
+// hasPathPrefix checks whether path is prefix or inside it
+func hasPathPrefix(path, prefix string) bool {
+	path, prefix = filepath.Clean(path), filepath.Clean(prefix)
+	if path == prefix {
+		return true
+	}
+	if !strings.HasSuffix(prefix, string(filepath.Separator)) {
+		prefix += string(filepath.Separator)
+	}
+	return strings.HasPrefix(path, prefix)
+}
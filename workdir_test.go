@@ -0,0 +1,53 @@
+package release
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestHasPathPrefix(t *testing.T) {
+	tests := []struct {
+		path, prefix string
+		want         bool
+	}{
+		{"/srv/app", "/srv/app", true},
+		{"/srv/app/data", "/srv/app", true},
+		{"/srv/app/", "/srv/app", true},
+		{"/srv/application", "/srv/app", false},
+		{"/", "/srv/app", false},
+		{"/srv/app", "/", true},
+	}
+
+	for _, tt := range tests {
+		path, prefix := filepath.FromSlash(tt.path), filepath.FromSlash(tt.prefix)
+		if got := hasPathPrefix(path, prefix); got != tt.want {
+			t.Errorf("hasPathPrefix(%s, %s) = %v, want %v", tt.path, tt.prefix, got, tt.want)
+		}
+	}
+}
+
+func TestWorkingDirCondition(t *testing.T) {
+	orig, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(orig)
+
+	cwd, err := CurrentWorkingDir()
+	if err != nil {
+		t.Fatalf("CurrentWorkingDir() error = %v", err)
+	}
+
+	if r := WorkingDirCondition(filepath.Dir(cwd)).evaluate(); !r.Passed {
+		t.Errorf("Parent prefix should pass: %+v", r)
+	}
+
+	r := WorkingDirCondition(filepath.Join(cwd, "elsewhere")).evaluate()
+	if r.Passed || r.Detail == "" {
+		t.Errorf("Unrelated prefix should fail with the actual cwd: %+v", r)
+	}
+}